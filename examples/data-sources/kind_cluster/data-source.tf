@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    kind = {
+      source = "elioseverojunior/kind"
+    }
+  }
+}
+
+provider "kind" {}
+
+# Read the connection details of a cluster created outside this configuration
+data "kind_cluster" "existing" {
+  name = "my-cluster"
+}
+
+output "endpoint" {
+  value = data.kind_cluster.existing.endpoint
+}
+
+output "kubeconfig" {
+  value     = data.kind_cluster.existing.kubeconfig
+  sensitive = true
+}
@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    kind = {
+      source = "elioseverojunior/kind"
+    }
+  }
+}
+
+provider "kind" {}
+
+# Resolve the digest-pinned node image for a Kubernetes version
+data "kind_node_image" "this" {
+  kubernetes_version = "1.35.0"
+}
+
+output "node_image" {
+  value = data.kind_node_image.this.image
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    kind = {
+      source = "elioseverojunior/kind"
+    }
+  }
+}
+
+provider "kind" {}
+
+# Validate a kubeadm patch during plan, without creating a cluster
+data "kind_patch_validation" "ingress_labels" {
+  type  = "merge"
+  patch = <<-YAML
+    kind: InitConfiguration
+    nodeRegistration:
+      kubeletExtraArgs:
+        node-labels: "ingress-ready=true"
+  YAML
+}
+
+output "patch_valid" {
+  value = data.kind_patch_validation.ingress_labels.valid
+}
+
+output "patch_error" {
+  value = data.kind_patch_validation.ingress_labels.error
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/kind/pkg/apis/config/defaults"
+)
+
+// tracingConfigFile is the API server tracing configuration, staged in
+// nodeFilesDir when api_server_tracing is set.
+const tracingConfigFile = "tracing-config.yaml"
+
+// apiServerTracingFeatureGate guards API server tracing. It is alpha and off
+// by default before Kubernetes 1.27, beta and on by default from 1.27.
+const apiServerTracingFeatureGate = "APIServerTracing"
+
+// tracingBetaVersion is the first Kubernetes version with the tracing gate on
+// by default and the v1beta1 TracingConfiguration API.
+var tracingBetaVersion = version.MajorMinor(1, 27)
+
+// nodeImageKubernetesVersion returns the Kubernetes version of the cluster's
+// node image, parsed from its tag. ok is false for tags that are not a
+// version, such as custom builds.
+func nodeImageKubernetesVersion(data *ClusterResourceModel) (*version.Version, bool) {
+	image := defaults.Image
+	if !data.NodeImage.IsNull() && !data.NodeImage.IsUnknown() && data.NodeImage.ValueString() != "" {
+		image = data.NodeImage.ValueString()
+	}
+
+	image, _, _ = strings.Cut(image, "@")
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return nil, false
+	}
+
+	v, err := version.ParseGeneric(image[i+1:])
+	if err != nil {
+		return nil, false
+	}
+
+	return v, true
+}
+
+// tracingNeedsFeatureGate reports whether the node image predates the tracing
+// gate being on by default, so api_server_tracing has to enable it.
+func tracingNeedsFeatureGate(data *ClusterResourceModel) bool {
+	if data.APIServerTracing == nil {
+		return false
+	}
+
+	v, ok := nodeImageKubernetesVersion(data)
+	return ok && v.LessThan(tracingBetaVersion)
+}
+
+// tracingConfig renders the API server TracingConfiguration, or an empty
+// string when api_server_tracing is not set. The endpoint is left to the API
+// server default, localhost:4317, when unset; the sampling rate defaults to
+// every request.
+func tracingConfig(data *ClusterResourceModel) string {
+	tracing := data.APIServerTracing
+	if tracing == nil {
+		return ""
+	}
+
+	apiVersion := "apiserver.config.k8s.io/v1beta1"
+	if v, ok := nodeImageKubernetesVersion(data); ok && v.LessThan(tracingBetaVersion) {
+		apiVersion = "apiserver.config.k8s.io/v1alpha1"
+	}
+
+	rate := 1.0
+	if !tracing.SamplingRate.IsNull() {
+		rate = tracing.SamplingRate.ValueFloat64()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "apiVersion: %s\nkind: TracingConfiguration\n", apiVersion)
+	if !tracing.Endpoint.IsNull() {
+		fmt.Fprintf(&b, "endpoint: %s\n", tracing.Endpoint.ValueString())
+	}
+	fmt.Fprintf(&b, "samplingRatePerMillion: %d\n", int64(math.Round(rate*1e6)))
+
+	return b.String()
+}
+
+// validateAPIServerTracing checks that the tracing endpoint names a host, that
+// the sampling rate is a fraction and that feature_gates does not disable
+// the gate tracing depends on.
+func validateAPIServerTracing(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	tracing := data.APIServerTracing
+	if tracing == nil {
+		return
+	}
+
+	// The port is checked by hostPortValidator.
+	if !tracing.Endpoint.IsNull() && !tracing.Endpoint.IsUnknown() {
+		if host, _, err := net.SplitHostPort(tracing.Endpoint.ValueString()); err == nil && host == "" {
+			diagnostics.AddAttributeError(
+				path.Root("api_server_tracing").AtName("endpoint"),
+				"Invalid Tracing Endpoint",
+				"endpoint must include the host of the OpenTelemetry collector, e.g. otel-collector:4317.",
+			)
+		}
+	}
+
+	if !tracing.SamplingRate.IsNull() && !tracing.SamplingRate.IsUnknown() {
+		if rate := tracing.SamplingRate.ValueFloat64(); rate < 0 || rate > 1 {
+			diagnostics.AddAttributeError(
+				path.Root("api_server_tracing").AtName("sampling_rate"),
+				"Invalid Sampling Rate",
+				fmt.Sprintf("sampling_rate must be between 0 and 1, got %g.", rate),
+			)
+		}
+	}
+
+	if gate, ok := data.FeatureGates.Elements()[apiServerTracingFeatureGate].(types.Bool); ok && !gate.IsNull() && !gate.IsUnknown() && !gate.ValueBool() {
+		diagnostics.AddAttributeError(
+			path.Root("feature_gates").AtMapKey(apiServerTracingFeatureGate),
+			"Conflicting Feature Gate",
+			"api_server_tracing requires the APIServerTracing feature gate, remove it from feature_gates or set it to true.",
+		)
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+const (
+	auditPolicyFile = "audit-policy.yaml"
+	auditLogDir     = "/var/log/kubernetes/audit"
+)
+
+var auditPolicyPresetNames = []string{"minimal", "metadata", "request", "request_response"}
+
+// auditPolicyPresets are the built-in audit policies selectable with
+// audit_policy_preset. Secrets, ConfigMaps and token reviews are always
+// logged at Metadata level so their payloads never end up in the audit log.
+var auditPolicyPresets = map[string]string{
+	"minimal": `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+  - RequestReceived
+rules:
+  - level: Metadata
+    verbs: ["create", "update", "patch", "delete", "deletecollection"]
+  - level: None
+`,
+	"metadata": `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+  - RequestReceived
+rules:
+  - level: Metadata
+`,
+	"request": `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+  - RequestReceived
+rules:
+  - level: Metadata
+    resources:
+      - group: ""
+        resources: ["secrets", "configmaps"]
+      - group: "authentication.k8s.io"
+        resources: ["tokenreviews"]
+  - level: Request
+`,
+	"request_response": `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+  - RequestReceived
+rules:
+  - level: Metadata
+    resources:
+      - group: ""
+        resources: ["secrets", "configmaps"]
+      - group: "authentication.k8s.io"
+        resources: ["tokenreviews"]
+  - level: RequestResponse
+`,
+}
+
+// auditPolicy returns the audit policy for the cluster: the explicit policy if
+// set, otherwise the selected preset, or an empty string when audit logging
+// is not configured.
+func auditPolicy(data *ClusterResourceModel) string {
+	if !data.AuditPolicy.IsNull() && data.AuditPolicy.ValueString() != "" {
+		return data.AuditPolicy.ValueString()
+	}
+
+	if !data.AuditPolicyPreset.IsNull() {
+		return auditPolicyPresets[data.AuditPolicyPreset.ValueString()]
+	}
+
+	return ""
+}
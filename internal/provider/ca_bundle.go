@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+)
+
+// nodeCABundlePath is where the shared CA bundle is installed in the node
+// trust store. update-ca-certificates only picks up .crt files there.
+const nodeCABundlePath = "/usr/local/share/ca-certificates/kind-cluster-ca-bundle.crt"
+
+// Defaults of the ConfigMap publishing the shared CA bundle to workloads.
+const (
+	defaultCABundleConfigMapName = "cluster-ca-bundle"
+	caBundleConfigMapKey         = "ca.crt"
+)
+
+// defaultCABundleNamespaces are the namespaces the CA bundle ConfigMap is
+// created in when cluster_ca_bundle.namespaces is not set.
+var defaultCABundleNamespaces = []string{metav1.NamespaceDefault}
+
+// applyClusterCABundle adds the shared CA bundle to the trust store of every
+// node.
+func applyClusterCABundle(ctx context.Context, provider *cluster.Provider, clusterName string, bundle *ClusterCABundleModel, diagnostics *diag.Diagnostics) {
+	if bundle == nil {
+		return
+	}
+
+	nodeList, err := provider.ListNodes(clusterName)
+	if err != nil {
+		diagnostics.AddError("Failed to install cluster CA bundle", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	if err := installNodeCABundle(ctx, nodeList, bundle.PEM.ValueString()); err != nil {
+		diagnostics.AddError("Failed to install cluster CA bundle", err.Error())
+	}
+}
+
+// installNodeCABundle writes the CA bundle into the given nodes' trust store,
+// regenerates it and restarts containerd so image pulls trust the CA too.
+func installNodeCABundle(ctx context.Context, nodeList []nodes.Node, pem string) error {
+	for _, node := range nodeList {
+		cmd := node.CommandContext(ctx, "sh", "-c", fmt.Sprintf("cat > %q && update-ca-certificates", nodeCABundlePath))
+		cmd.SetStdin(strings.NewReader(pem))
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("installing the CA bundle on node %s failed: %w", node.String(), err)
+		}
+
+		if err := node.CommandContext(ctx, "systemctl", "restart", "containerd").Run(); err != nil {
+			return fmt.Errorf("restarting containerd on node %s failed: %w", node.String(), err)
+		}
+	}
+
+	return nil
+}
+
+// createCABundleConfigMaps publishes the CA bundle as a ConfigMap in each of
+// the configured namespaces, creating them if needed, for workloads to mount.
+// Existing ConfigMaps with the same name are left in place.
+func createCABundleConfigMaps(ctx context.Context, clientset kubernetes.Interface, bundle *ClusterCABundleModel) error {
+	name := bundle.ConfigMapName.ValueString()
+	if name == "" {
+		name = defaultCABundleConfigMapName
+	}
+
+	namespaces := listStringValues(bundle.Namespaces)
+	if bundle.Namespaces.IsNull() {
+		namespaces = defaultCABundleNamespaces
+	}
+
+	for _, namespace := range namespaces {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		if _, err := clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace %q: %w", namespace, err)
+		}
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       map[string]string{caBundleConfigMapKey: bundle.PEM.ValueString()},
+		}
+		if _, err := clientset.CoreV1().ConfigMaps(namespace).Create(ctx, configMap, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create ConfigMap %q in namespace %q: %w", name, namespace, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+)
+
+// defaultCertManagerVersion is the cert-manager release installed when
+// cert_manager_version is not set.
+const defaultCertManagerVersion = "v1.19.1"
+
+// certManagerManifestURL is the release asset holding every cert-manager
+// resource, formatted with the release version.
+const certManagerManifestURL = "https://github.com/cert-manager/cert-manager/releases/download/%s/cert-manager.yaml"
+
+// cert-manager's webhook deployment. Issuers and certificates cannot be
+// created until it serves, so the install waits for it.
+const (
+	certManagerNamespace = "cert-manager"
+	certManagerWebhook   = "cert-manager-webhook"
+)
+
+// certManagerVersion returns the configured cert-manager version or the
+// pinned default.
+func certManagerVersion(data *ClusterResourceModel) string {
+	if data.CertManagerVersion.IsNull() || data.CertManagerVersion.IsUnknown() || data.CertManagerVersion.ValueString() == "" {
+		return defaultCertManagerVersion
+	}
+
+	return data.CertManagerVersion.ValueString()
+}
+
+// installCertManager applies the cert-manager release manifests with the
+// kubectl of the bootstrap control-plane node and waits for the webhook to
+// become available within wait_for_ready.
+func (r *ClusterResource) installCertManager(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	version := certManagerVersion(data)
+
+	manifest, err := fetchManifest(ctx, fmt.Sprintf(certManagerManifestURL, version))
+	if err != nil {
+		diagnostics.AddError("Failed to install cert-manager", fmt.Sprintf("Downloading cert-manager %s failed: %s", version, err))
+		return
+	}
+
+	nodeList, err := r.provider.ListNodes(data.Name.ValueString())
+	if err != nil {
+		diagnostics.AddError("Failed to install cert-manager", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	controlPlane, err := nodeutils.BootstrapControlPlaneNode(nodeList)
+	if err != nil {
+		diagnostics.AddError("Failed to install cert-manager", err.Error())
+		return
+	}
+
+	err = newManifestApplier(data).run(ctx, func(ctx context.Context) error {
+		return applyManifestInNode(ctx, controlPlane, manifest)
+	})
+	if err != nil {
+		diagnostics.AddError("Failed to install cert-manager", fmt.Sprintf("Applying cert-manager %s failed: %s", version, err))
+		return
+	}
+
+	clientset, err := newKubernetesClientset(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddError("Failed to install cert-manager", err.Error())
+		return
+	}
+
+	timeout := time.Duration(data.WaitForReady.ValueInt64()) * time.Second
+	if err := waitForDeploymentAvailable(ctx, clientset, certManagerNamespace, certManagerWebhook, timeout); err != nil {
+		diagnostics.AddError("cert-manager webhook not ready", err.Error())
+	}
+}
+
+// fetchManifest downloads a manifest over HTTP.
+func fetchManifest(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// waitForDeploymentAvailable polls a deployment until its Available
+// condition is true. On timeout the error describes the deployment's last
+// observed readiness.
+func waitForDeploymentAvailable(ctx context.Context, clientset kubernetes.Interface, namespace, name string, timeout time.Duration) error {
+	ticker := time.NewTicker(defaultNodeReadyWaiter.pollInterval)
+	defer ticker.Stop()
+
+	timeoutCh := time.After(timeout)
+	status := "the deployment was not found"
+
+	for {
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			if deploymentAvailable(deployment) {
+				return nil
+			}
+			status = deploymentStatus(deployment)
+		} else {
+			status = err.Error()
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("deployment %s/%s is not available: %s: %w", namespace, name, status, ctx.Err())
+		case <-timeoutCh:
+			return fmt.Errorf("deployment %s/%s is not available after %s: %s", namespace, name, timeout, status)
+		case <-ticker.C:
+		}
+	}
+}
+
+// deploymentAvailable reports whether a deployment has its Available
+// condition set to true.
+func deploymentAvailable(deployment *appsv1.Deployment) bool {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// deploymentStatus summarises a deployment's replicas and conditions.
+func deploymentStatus(deployment *appsv1.Deployment) string {
+	parts := []string{fmt.Sprintf("%d/%d replicas available", deployment.Status.AvailableReplicas, deployment.Status.Replicas)}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Message != "" {
+			parts = append(parts, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+		}
+	}
+
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// highestUserDefinablePriority is the largest value the API server accepts for
+// PriorityClasses that are not built-in system classes.
+const highestUserDefinablePriority = 1000000000
+
+// preemptionPolicies are the PriorityClass preemption policies Kubernetes accepts.
+var preemptionPolicies = []string{string(corev1.PreemptLowerPriority), string(corev1.PreemptNever)}
+
+// bootstrapCluster creates the Kubernetes objects requested in the resource
+// configuration once the cluster is up. Unlike the status readers, failures
+// here are errors because the cluster would not match its configuration.
+func (r *ClusterResource) bootstrapCluster(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.DefaultRuntimeClass == nil && len(data.PriorityClasses) == 0 && len(data.NamespacePolicies) == 0 && data.ClusterCABundle == nil && data.RBAC == nil && data.LocalRegistry == nil {
+		return
+	}
+
+	clientset, err := newKubernetesClientset(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddError("Failed to bootstrap cluster", err.Error())
+		return
+	}
+
+	applier := newManifestApplier(data)
+
+	if data.DefaultRuntimeClass != nil {
+		err := applier.run(ctx, func(ctx context.Context) error {
+			return createRuntimeClass(ctx, clientset, data.DefaultRuntimeClass)
+		})
+		if err != nil {
+			diagnostics.AddError("Failed to create RuntimeClass", err.Error())
+			return
+		}
+	}
+
+	for i := range data.PriorityClasses {
+		err := applier.run(ctx, func(ctx context.Context) error {
+			return createPriorityClass(ctx, clientset, &data.PriorityClasses[i])
+		})
+		if err != nil {
+			diagnostics.AddError("Failed to create PriorityClass", err.Error())
+			return
+		}
+	}
+
+	for i := range data.NamespacePolicies {
+		err := applier.run(ctx, func(ctx context.Context) error {
+			return applyNamespacePolicy(ctx, clientset, &data.NamespacePolicies[i])
+		})
+		if err != nil {
+			diagnostics.AddError("Failed to apply namespace policy", err.Error())
+			return
+		}
+	}
+
+	if data.RBAC != nil {
+		err := applier.run(ctx, func(ctx context.Context) error {
+			return applyRBACManifests(ctx, clientset, listStringValues(data.RBAC.Manifests))
+		})
+		if err != nil {
+			diagnostics.AddError("Failed to apply RBAC manifests", err.Error())
+			return
+		}
+	}
+
+	if data.ClusterCABundle != nil {
+		err := applier.run(ctx, func(ctx context.Context) error {
+			return createCABundleConfigMaps(ctx, clientset, data.ClusterCABundle)
+		})
+		if err != nil {
+			diagnostics.AddError("Failed to publish cluster CA bundle", err.Error())
+			return
+		}
+	}
+
+	if data.LocalRegistry != nil {
+		err := applier.run(ctx, func(ctx context.Context) error {
+			return createLocalRegistryHostingConfigMap(ctx, clientset, data.LocalRegistry)
+		})
+		if err != nil {
+			diagnostics.AddError("Failed to publish local registry", err.Error())
+			return
+		}
+	}
+}
+
+// createRuntimeClass creates the configured RuntimeClass, leaving an existing
+// object with the same name in place.
+func createRuntimeClass(ctx context.Context, clientset kubernetes.Interface, rc *RuntimeClassModel) error {
+	runtimeClass := &nodev1.RuntimeClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rc.Name.ValueString(),
+		},
+		Handler: rc.Handler.ValueString(),
+	}
+
+	_, err := clientset.NodeV1().RuntimeClasses().Create(ctx, runtimeClass, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create RuntimeClass %q: %w", runtimeClass.Name, err)
+	}
+
+	return nil
+}
+
+// createPriorityClass creates the configured PriorityClass, leaving an existing
+// object with the same name in place.
+func createPriorityClass(ctx context.Context, clientset kubernetes.Interface, pc *PriorityClassModel) error {
+	priorityClass := &schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: pc.Name.ValueString(),
+		},
+		Value:         int32(pc.Value.ValueInt64()),
+		GlobalDefault: pc.GlobalDefault.ValueBool(),
+	}
+
+	if !pc.PreemptionPolicy.IsNull() {
+		policy := corev1.PreemptionPolicy(pc.PreemptionPolicy.ValueString())
+		priorityClass.PreemptionPolicy = &policy
+	}
+
+	_, err := clientset.SchedulingV1().PriorityClasses().Create(ctx, priorityClass, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create PriorityClass %q: %w", priorityClass.Name, err)
+	}
+
+	return nil
+}
+
+// validatePriorityClasses checks that at most one PriorityClass is the global
+// default and that none uses the reserved system- prefix.
+func validatePriorityClasses(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	var globalDefaults []string
+	for i, pc := range data.PriorityClasses {
+		if !pc.Name.IsUnknown() && strings.HasPrefix(pc.Name.ValueString(), "system-") {
+			diagnostics.AddAttributeError(
+				path.Root("priority_classes").AtListIndex(i).AtName("name"),
+				"Reserved PriorityClass Name",
+				fmt.Sprintf("%q uses the system- prefix, which is reserved for Kubernetes built-in classes.", pc.Name.ValueString()),
+			)
+		}
+
+		if pc.GlobalDefault.ValueBool() {
+			globalDefaults = append(globalDefaults, pc.Name.ValueString())
+		}
+	}
+
+	if len(globalDefaults) > 1 {
+		diagnostics.AddAttributeError(
+			path.Root("priority_classes"),
+			"Multiple Global Default PriorityClasses",
+			fmt.Sprintf("Only one PriorityClass may set global_default, found: %s.", strings.Join(globalDefaults, ", ")),
+		)
+	}
+}
+
+// validateRuntimeClass checks that the default RuntimeClass refers to a
+// runtime handler the node containerd will know about and, when preferred,
+// that containerd_config_patches do not pick another default runtime.
+func validateRuntimeClass(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	rc := data.DefaultRuntimeClass
+	if rc == nil {
+		return
+	}
+
+	if rc.Handler.IsUnknown() || data.ContainerdConfigPatches.IsUnknown() {
+		return
+	}
+
+	handlers, err := containerdRuntimeHandlers(listStringValues(data.ContainerdConfigPatches))
+	if err != nil {
+		// Invalid patches are reported by validateConfigPatches.
+		return
+	}
+
+	if !slices.Contains(handlers, rc.Handler.ValueString()) {
+		diagnostics.AddAttributeError(
+			path.Root("default_runtime_class").AtName("handler"),
+			"Unknown Runtime Handler",
+			fmt.Sprintf("Runtime handler %q is not configured on the nodes. Available handlers: %s. "+
+				"Declare additional handlers in containerd_config_patches.",
+				rc.Handler.ValueString(), strings.Join(handlers, ", ")),
+		)
+	}
+
+	if !rc.Preferred.ValueBool() {
+		return
+	}
+
+	if name, ok := containerdDefaultRuntime(listStringValues(data.ContainerdConfigPatches)); ok && name != rc.Handler.ValueString() {
+		diagnostics.AddAttributeError(
+			path.Root("default_runtime_class").AtName("preferred"),
+			"Conflicting Containerd Setting",
+			fmt.Sprintf("containerd_config_patches sets %s = %q, which contradicts the preferred handler %q. Remove it from the patch.",
+				criDefaultRuntimeKey, name, rc.Handler.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+// defaultCreateRetries is how many times a failed cluster creation is retried.
+const defaultCreateRetries = 2
+
+// createRetryBaseDelay is the wait before the first retry, doubled for each
+// one after it.
+const createRetryBaseDelay = 5 * time.Second
+
+// clusterExistsMessage is the error KinD's create returns, before creating
+// anything, when nodes of a cluster with the same name already exist.
+const clusterExistsMessage = "node(s) already exist for a cluster with the name"
+
+// isClusterExistsError reports whether err is KinD refusing to create a
+// cluster whose nodes already exist.
+func isClusterExistsError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), clusterExistsMessage)
+}
+
+// permanentCreateErrors are fragments of KinD create errors that another
+// attempt cannot fix: an existing cluster, a configuration KinD rejects or an
+// image that cannot be pulled.
+var permanentCreateErrors = []string{
+	clusterExistsMessage,
+	"is not a valid cluster name",
+	"invalid ",
+	"failed to pull image",
+	"rootless provider requires",
+}
+
+// isPermanentCreateError reports whether err is a create failure retrying
+// would only repeat.
+func isPermanentCreateError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	for _, fragment := range permanentCreateErrors {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createWithRetries runs KinD's create, retrying failures up to retries times
+// with exponential backoff. Busy hosts see transient Docker failures such as
+// ports still allocated or a daemon refusing connections. The partially created
+// cluster is deleted between attempts so the next one does not fail with the
+// cluster already existing; after the last attempt it is left to the caller.
+// Permanent failures are returned right away without deleting anything, so a
+// cluster that existed before the first attempt is never deleted. It returns
+// the number of attempts made and the last error.
+func (r *ClusterResource) createWithRetries(ctx context.Context, clusterName, kubeconfigPath string, retries int, opts []cluster.CreateOption) (int, error) {
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		if err = r.provider.Create(clusterName, opts...); err == nil || attempt > retries || isPermanentCreateError(err) {
+			return attempt, err
+		}
+
+		// A delete failure resurfaces as the next attempt's error.
+		_ = r.provider.Delete(clusterName, kubeconfigPath)
+
+		delay := createRetryBaseDelay << (attempt - 1)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// configureNodes applies the node-level settings KinD has no option for to the
+// nodes of a freshly created cluster: extra args, ulimits, registry
+// certificates and mirrors, the CA bundle, the timezone and preloaded images.
+func (r *ClusterResource) configureNodes(ctx context.Context, data *ClusterResourceModel, cfg *v1alpha4.Cluster, diagnostics *diag.Diagnostics) {
+	clusterName := data.Name.ValueString()
+
+	applyNodeExtraArgs(ctx, r.runtime, clusterName, data.Nodes, cfg.Nodes, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	applyNodeUlimits(ctx, r.provider, clusterName, data.Nodes, cfg.Nodes, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	applyRegistryCerts(ctx, r.provider, clusterName, stringMapValue(data.RegistryCerts), diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	applyRegistryMirrors(ctx, r.provider, clusterName, registryMirrors(data), stringMapValue(data.RegistryCerts), diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	applyClusterCABundle(ctx, r.provider, clusterName, data.ClusterCABundle, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	if !data.NodeTimezone.IsNull() {
+		applyNodeTimezone(ctx, r.provider, clusterName, nodeTimezone(data.NodeTimezone), diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	withImageCacheLock(ctx, data, diagnostics, func() {
+		r.loadImages(ctx, clusterName, listStringValues(data.LoadedImages), diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+
+		r.loadArchives(ctx, clusterName, listStringValues(data.ImageArchives), diagnostics)
+	})
+}
+
+// validateStopBeforeKubernetes rejects options that talk to the API server,
+// which does not run when stop_before_kubernetes is set.
+func validateStopBeforeKubernetes(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if !data.StopBeforeKubernetes.ValueBool() {
+		return
+	}
+
+	var conflicting []string
+	if !data.CNIManifest.IsNull() {
+		conflicting = append(conflicting, "cni_manifest")
+	}
+	if data.AutoApproveKubeletCerts.ValueBool() {
+		conflicting = append(conflicting, "auto_approve_kubelet_certs")
+	}
+	if data.CoreDNS != nil {
+		conflicting = append(conflicting, "coredns")
+	}
+	if data.InstallNodeLocalDNS.ValueBool() {
+		conflicting = append(conflicting, "install_node_local_dns")
+	}
+	if data.InstallCertManager.ValueBool() {
+		conflicting = append(conflicting, "install_cert_manager")
+	}
+	if !data.ApplyManifests.IsNull() {
+		conflicting = append(conflicting, "apply_manifests")
+	}
+	if data.LocalRegistry != nil {
+		conflicting = append(conflicting, "local_registry")
+	}
+	if !data.RestartWorkloads.IsNull() {
+		conflicting = append(conflicting, "restart_workloads")
+	}
+	if !data.WaitForDeployments.IsNull() {
+		conflicting = append(conflicting, "wait_for_deployments")
+	}
+	if data.DrainBeforeDelete != nil {
+		conflicting = append(conflicting, "drain_before_delete")
+	}
+	for _, node := range data.Nodes {
+		if !node.ExtraLabels.IsNull() {
+			conflicting = append(conflicting, "node.extra_labels")
+			break
+		}
+	}
+	if len(conflicting) == 0 {
+		return
+	}
+
+	diagnostics.AddAttributeError(
+		path.Root("stop_before_kubernetes"),
+		"Options Need a Running Cluster",
+		"stop_before_kubernetes leaves the cluster without an API server, which "+strings.Join(conflicting, ", ")+
+			" need. Remove them or apply them after finishing the bootstrap.",
+	)
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -10,69 +11,126 @@ import (
 	"sigs.k8s.io/kind/pkg/cluster"
 )
 
-var _ datasource.DataSource = &ClustersDataSource{}
+var _ datasource.DataSource = &ClusterDataSource{}
 
-type ClustersDataSource struct {
+type ClusterDataSource struct {
 	provider *cluster.Provider
 }
 
-func NewClustersDataSource() datasource.DataSource {
-	return &ClustersDataSource{}
+func NewClusterDataSource() datasource.DataSource {
+	return &ClusterDataSource{}
 }
 
-func (d *ClustersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_clusters"
+func (d *ClusterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster"
 }
 
-func (d *ClustersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *ClusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "List all KinD clusters.",
+		Description: "Read the connection details of an existing KinD cluster.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Data source identifier.",
+				Description: "Cluster identifier (same as name).",
 				Computed:    true,
 			},
-			"clusters": schema.ListAttribute{
-				Description: "List of cluster names.",
+			"name": schema.StringAttribute{
+				Description: "Name of the existing cluster.",
+				Required:    true,
+			},
+			"kubeconfig": schema.StringAttribute{
+				Description: "Kubeconfig content for the cluster.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"kubeconfig_path": schema.StringAttribute{
+				Description: "Path to the kubeconfig file.",
+				Computed:    true,
+			},
+			"client_certificate": schema.StringAttribute{
+				Description: "Client certificate for authenticating to the cluster (base64).",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "Client key for authenticating to the cluster (base64).",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Description: "Cluster CA certificate (base64).",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"endpoint": schema.StringAttribute{
+				Description: "Kubernetes API server endpoint.",
 				Computed:    true,
-				ElementType: types.StringType,
 			},
 		},
 	}
 }
 
-func (d *ClustersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+func (d *ClusterDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	provider, ok := req.ProviderData.(*cluster.Provider)
+	providerData, ok := req.ProviderData.(*KindProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cluster.Provider, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *KindProviderData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	d.provider = provider
+	d.provider = providerData.Provider
 }
 
-func (d *ClustersDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterName := data.Name.ValueString()
+
 	clusters, err := d.provider.List()
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to list clusters", err.Error())
 		return
 	}
 
-	data := ClustersDataSourceModel{
-		ID:       types.StringValue("kind-clusters"),
-		Clusters: make([]types.String, len(clusters)),
+	if !slices.Contains(clusters, clusterName) {
+		resp.Diagnostics.AddError("Cluster not found", fmt.Sprintf("KinD cluster %q does not exist.", clusterName))
+		return
 	}
 
-	for i, c := range clusters {
-		data.Clusters[i] = types.StringValue(c)
+	kubeconfig, err := d.provider.KubeConfig(clusterName, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get kubeconfig", err.Error())
+		return
 	}
 
+	kubeconfigPath, err := kindKubeconfigPath(clusterName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get home directory", err.Error())
+		return
+	}
+
+	creds, err := parseKubeconfigCredentials(kubeconfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse kubeconfig", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(clusterName)
+	data.Kubeconfig = types.StringValue(kubeconfig)
+	data.KubeconfigPath = types.StringValue(kubeconfigPath)
+	data.Endpoint = types.StringValue(creds.Endpoint)
+	data.ClusterCaCertificate = types.StringValue(creds.ClusterCaCertificate)
+	data.ClientCertificate = types.StringValue(creds.ClientCertificate)
+	data.ClientKey = types.StringValue(creds.ClientKey)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
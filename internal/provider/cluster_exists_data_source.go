@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+var _ datasource.DataSource = &ClusterExistsDataSource{}
+
+type ClusterExistsDataSource struct {
+	provider *cluster.Provider
+}
+
+func NewClusterExistsDataSource() datasource.DataSource {
+	return &ClusterExistsDataSource{}
+}
+
+func (d *ClusterExistsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_exists"
+}
+
+func (d *ClusterExistsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Check whether a KinD cluster with the given name exists, without managing or reading it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (same as name).",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the cluster to look for.",
+				Required:    true,
+			},
+			"exists": schema.BoolAttribute{
+				Description: "Whether a cluster with this name exists.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ClusterExistsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KindProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *KindProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.provider = providerData.Provider
+}
+
+func (d *ClusterExistsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterExistsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusters, err := d.provider.List()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list clusters", err.Error())
+		return
+	}
+
+	data.ID = data.Name
+	data.Exists = types.BoolValue(slices.Contains(clusters, data.Name.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
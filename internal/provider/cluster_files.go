@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// nodeFilesDir is where provider-generated files are mounted inside the
+// control-plane node containers.
+const nodeFilesDir = "/etc/kubernetes/terraform"
+
+// clusterFiles returns the files the provider generates for the control-plane
+// nodes, keyed by file name within nodeFilesDir.
+func clusterFiles(data *ClusterResourceModel) map[string]string {
+	files := map[string]string{}
+
+	if policy := auditPolicy(data); policy != "" {
+		files[auditPolicyFile] = policy
+	}
+
+	if config := admissionConfig(data); config != "" {
+		files[admissionConfigFile] = config
+	}
+
+	if config := tracingConfig(data); config != "" {
+		files[tracingConfigFile] = config
+	}
+
+	return files
+}
+
+// clusterFilesHostDir returns the host directory holding the generated files
+// for a cluster.
+func clusterFilesHostDir(clusterName string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, ".kube", "kind", "files", clusterName), nil
+}
+
+// stageClusterFiles writes the generated files to the host and mounts their
+// directory into every control-plane node at nodeFilesDir. The files must stay
+// on the host for as long as the cluster exists, since the mount is re-read
+// whenever a node container restarts.
+func stageClusterFiles(clusterName string, cfg *v1alpha4.Cluster, files map[string]string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	dir, err := clusterFilesHostDir(clusterName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	for i := range cfg.Nodes {
+		if cfg.Nodes[i].Role != v1alpha4.ControlPlaneRole {
+			continue
+		}
+		cfg.Nodes[i].ExtraMounts = append(cfg.Nodes[i].ExtraMounts, v1alpha4.Mount{
+			HostPath:      dir,
+			ContainerPath: nodeFilesDir,
+			Readonly:      true,
+		})
+	}
+
+	return nil
+}
+
+// removeClusterFiles deletes the generated files of a cluster, if any.
+func removeClusterFiles(clusterName string) error {
+	dir, err := clusterFilesHostDir(clusterName)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
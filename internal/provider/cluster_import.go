@@ -0,0 +1,362 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/yaml"
+)
+
+// importedPrivateKey marks, in private state, a resource imported but not yet
+// read, whose configuration Read reconstructs from the running cluster.
+const importedPrivateKey = "imported"
+
+// apiServerContainerPort is the port the API server listens on inside the
+// control-plane nodes, which KinD publishes on the host.
+const apiServerContainerPort = 6443
+
+// kindnetDaemonSet and kubeProxyConfigMap are the kube-system objects telling
+// whether the default CNI and kube-proxy were installed.
+const (
+	kindnetDaemonSet   = "kindnet"
+	kubeProxyConfigMap = "kube-proxy"
+)
+
+// kindOwnedMounts are the node container paths KinD and the provider mount
+// themselves, which are not extra_mounts.
+var kindOwnedMounts = []string{"/lib/modules", "/dev/mapper", nodeFilesDir, nodeContentStoreDir}
+
+// setImportDefaults sets the attributes with a static default to it, as a
+// plan would, so the first plan after import does not show them changing from
+// null, which replaces the cluster for most of them.
+func (r *ClusterResource) setImportDefaults(ctx context.Context, state *tfsdk.State, diagnostics *diag.Diagnostics) {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	for name, attribute := range schemaResp.Schema.Attributes {
+		attrPath := path.Root(name)
+		switch a := attribute.(type) {
+		case schema.BoolAttribute:
+			if a.Default == nil {
+				continue
+			}
+			resp := &defaults.BoolResponse{}
+			a.Default.DefaultBool(ctx, defaults.BoolRequest{Path: attrPath}, resp)
+			diagnostics.Append(resp.Diagnostics...)
+			diagnostics.Append(state.SetAttribute(ctx, attrPath, resp.PlanValue)...)
+		case schema.Int64Attribute:
+			if a.Default == nil {
+				continue
+			}
+			resp := &defaults.Int64Response{}
+			a.Default.DefaultInt64(ctx, defaults.Int64Request{Path: attrPath}, resp)
+			diagnostics.Append(resp.Diagnostics...)
+			diagnostics.Append(state.SetAttribute(ctx, attrPath, resp.PlanValue)...)
+		}
+	}
+}
+
+// reconstructClusterConfig fills the node and networking configuration of an
+// imported cluster from its node containers and kube-system objects. Only
+// values that differ from KinD's defaults are set, and whatever cannot be
+// recovered, such as node labels, patches or a port KinD may have picked, is
+// left null, so a configuration relying on the defaults plans no change.
+// Failing to read the Kubernetes objects only warns.
+func (r *ClusterResource) reconstructClusterConfig(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	clusterName := data.Name.ValueString()
+
+	nodeList, err := r.provider.ListNodes(clusterName)
+	if err != nil {
+		diagnostics.AddError("Failed to list cluster nodes", err.Error())
+		return
+	}
+
+	type importedNode struct {
+		name    string
+		role    string
+		inspect *containerInspect
+	}
+
+	var nodes []importedNode
+	for _, node := range nodeList {
+		role, err := node.Role()
+		if err != nil {
+			diagnostics.AddError("Failed to get node role", err.Error())
+			return
+		}
+		if role == constants.ExternalLoadBalancerNodeRoleValue {
+			continue
+		}
+
+		inspected, err := inspectContainer(ctx, r.runtime, node.String())
+		if err != nil {
+			diagnostics.AddError("Failed to inspect node", err.Error())
+			return
+		}
+		nodes = append(nodes, importedNode{name: node.String(), role: role, inspect: inspected})
+	}
+
+	if len(nodes) == 0 {
+		return
+	}
+
+	// Control planes come first, then workers, each in the order KinD
+	// numbered their containers.
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].role != nodes[j].role {
+			return nodes[i].role == constants.ControlPlaneNodeRoleValue
+		}
+		return nodeOrdinal(clusterName, nodes[i].role, nodes[i].name) < nodeOrdinal(clusterName, nodes[j].role, nodes[j].name)
+	})
+
+	networking := &NetworkingModel{DNSSearch: types.ListNull(types.StringType)}
+	r.reconstructNetworking(ctx, data, networking, diagnostics)
+
+	// Per-node images are the ones differing from the first control plane's,
+	// which populateComputedValues records as node_image.
+	clusterImage := nodes[0].inspect.Config.Image
+
+	customized := false
+	models := make([]NodeModel, len(nodes))
+	for i, node := range nodes {
+		models[i] = NodeModel{
+			Role:                 types.StringValue(node.role),
+			Image:                types.StringNull(),
+			Labels:               types.MapNull(types.StringType),
+			ExtraLabels:          types.MapNull(types.StringType),
+			ExtraArgs:            types.ListNull(types.StringType),
+			Ulimits:              types.MapNull(types.StringType),
+			KubeletExtraArgs:     types.MapNull(types.StringType),
+			KubeadmConfigPatches: types.ListNull(types.StringType),
+			ExtraMounts:          importedMounts(node.inspect.HostConfig.Binds),
+			ExtraPortMappings:    importedPortMappings(node.inspect, node.role == constants.ControlPlaneNodeRoleValue, networking),
+		}
+		if image := node.inspect.Config.Image; image != clusterImage {
+			models[i].Image = types.StringValue(image)
+		}
+
+		if !models[i].Image.IsNull() || len(models[i].ExtraMounts) > 0 || len(models[i].ExtraPortMappings) > 0 {
+			customized = true
+		}
+
+		for _, env := range node.inspect.Config.Env {
+			if search, ok := strings.CutPrefix(env, "KIND_DNS_SEARCH="); ok && networking.DNSSearch.IsNull() {
+				networking.DNSSearch, _ = types.ListValueFrom(ctx, types.StringType, strings.Fields(search))
+			}
+		}
+	}
+
+	// KinD's default topology is what a configuration without node blocks
+	// creates.
+	isDefault := slices.EqualFunc(nodes, defaultNodes(), func(node importedNode, defaulted v1alpha4.Node) bool {
+		return node.role == string(defaulted.Role)
+	})
+	if !isDefault || customized {
+		data.Nodes = models
+	}
+
+	for _, value := range []attr.Value{networking.IPFamily, networking.APIServerAddress, networking.PodSubnet, networking.ServiceSubnet, networking.DisableDefaultCNI, networking.KubeProxyMode, networking.DNSSearch} {
+		if !value.IsNull() {
+			data.Networking = networking
+			break
+		}
+	}
+}
+
+// reconstructNetworking sets the networking values of an imported cluster
+// that differ from KinD's defaults, read from the kubeadm ClusterConfiguration
+// and the kube-system objects.
+func (r *ClusterResource) reconstructNetworking(ctx context.Context, data *ClusterResourceModel, networking *NetworkingModel, diagnostics *diag.Diagnostics) {
+	kubeconfig, err := r.provider.KubeConfig(data.Name.ValueString(), false)
+	if err != nil {
+		diagnostics.AddWarning("Failed to read the cluster networking", err.Error())
+		return
+	}
+	clientset, err := newKubernetesClientset(kubeconfig)
+	if err != nil {
+		diagnostics.AddWarning("Failed to read the cluster networking", err.Error())
+		return
+	}
+
+	configMap, err := clientset.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(ctx, kubeadmConfigConfigMap, metav1.GetOptions{})
+	if err != nil {
+		diagnostics.AddWarning("Failed to read the cluster networking", "Could not read the kubeadm-config ConfigMap: "+err.Error())
+		return
+	}
+
+	var clusterConfig struct {
+		Networking struct {
+			PodSubnet     string `json:"podSubnet"`
+			ServiceSubnet string `json:"serviceSubnet"`
+		} `json:"networking"`
+	}
+	if err := yaml.Unmarshal([]byte(configMap.Data[kubeadmClusterConfigurationKey]), &clusterConfig); err != nil {
+		diagnostics.AddWarning("Failed to read the cluster networking", "Could not parse the ClusterConfiguration: "+err.Error())
+		return
+	}
+
+	podSubnet := clusterConfig.Networking.PodSubnet
+	family := v1alpha4.IPv4Family
+	if strings.Contains(podSubnet, ",") {
+		family = v1alpha4.DualStackFamily
+	} else if ip, _, err := net.ParseCIDR(podSubnet); err == nil && ip.To4() == nil {
+		family = v1alpha4.IPv6Family
+	}
+
+	defaulted := &v1alpha4.Cluster{Networking: v1alpha4.Networking{IPFamily: family}}
+	v1alpha4.SetDefaultsCluster(defaulted)
+
+	if family != v1alpha4.IPv4Family {
+		networking.IPFamily = types.StringValue(string(family))
+	}
+	if podSubnet != "" && podSubnet != defaulted.Networking.PodSubnet {
+		networking.PodSubnet = types.StringValue(podSubnet)
+	}
+	if serviceSubnet := clusterConfig.Networking.ServiceSubnet; serviceSubnet != "" && serviceSubnet != defaulted.Networking.ServiceSubnet {
+		networking.ServiceSubnet = types.StringValue(serviceSubnet)
+	}
+
+	if _, err := clientset.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(ctx, kindnetDaemonSet, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		networking.DisableDefaultCNI = types.BoolValue(true)
+	}
+
+	proxyConfig, err := clientset.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(ctx, kubeProxyConfigMap, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		networking.KubeProxyMode = types.StringValue(string(noneProxyMode))
+	case err == nil:
+		var proxy struct {
+			Mode string `json:"mode"`
+		}
+		if err := yaml.Unmarshal([]byte(proxyConfig.Data["config.conf"]), &proxy); err == nil && proxy.Mode != "" && proxy.Mode != string(defaulted.Networking.KubeProxyMode) {
+			networking.KubeProxyMode = types.StringValue(proxy.Mode)
+		}
+	}
+}
+
+// nodeOrdinal returns the number KinD gave a node container of a role: 1 for
+// the unnumbered first one, then 2, 3 and so on.
+func nodeOrdinal(clusterName, role, containerName string) int {
+	suffix := strings.TrimPrefix(containerName, clusterName+"-"+role)
+	if suffix == "" {
+		return 1
+	}
+
+	n, err := strconv.Atoi(suffix)
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
+
+// importedMounts converts the bind mounts of a node container back to
+// extra_mounts, skipping the ones KinD and the provider add. Binds are
+// host:container[:options] as KinD generates them.
+func importedMounts(binds []string) []MountModel {
+	var mounts []MountModel
+	for _, bind := range binds {
+		parts := strings.SplitN(bind, ":", 3)
+		if len(parts) < 2 || slices.Contains(kindOwnedMounts, parts[1]) {
+			continue
+		}
+
+		mount := MountModel{
+			HostPath:       types.StringValue(parts[0]),
+			ContainerPath:  types.StringValue(parts[1]),
+			ReadOnly:       types.BoolNull(),
+			SelinuxRelabel: types.BoolNull(),
+			Propagation:    types.StringNull(),
+		}
+		if len(parts) == 3 {
+			for _, option := range strings.Split(parts[2], ",") {
+				switch option {
+				case "ro":
+					mount.ReadOnly = types.BoolValue(true)
+				case "Z":
+					mount.SelinuxRelabel = types.BoolValue(true)
+				case "rshared":
+					mount.Propagation = types.StringValue(string(v1alpha4.MountPropagationBidirectional))
+				case "rslave":
+					mount.Propagation = types.StringValue(string(v1alpha4.MountPropagationHostToContainer))
+				}
+			}
+		}
+		mounts = append(mounts, mount)
+	}
+
+	return mounts
+}
+
+// importedPortMappings converts the published ports of a node container back
+// to extra_port_mappings, sorted by container port. The API server port of a
+// control-plane node is KinD's own; its address is recorded in networking
+// when it is not the default one.
+func importedPortMappings(inspected *containerInspect, controlPlane bool, networking *NetworkingModel) []PortMappingModel {
+	defaultListen := "0.0.0.0"
+	if networking.IPFamily.ValueString() == string(v1alpha4.IPv6Family) {
+		defaultListen = "::"
+	}
+
+	var mappings []PortMappingModel
+	for port, bindings := range inspected.HostConfig.PortBindings {
+		portNumber, protocol, _ := strings.Cut(port, "/")
+		containerPort, err := strconv.ParseInt(portNumber, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		for _, binding := range bindings {
+			if controlPlane && containerPort == apiServerContainerPort {
+				if binding.HostIP != "" && binding.HostIP != "127.0.0.1" {
+					networking.APIServerAddress = types.StringValue(binding.HostIP)
+				}
+				continue
+			}
+
+			hostPort, err := strconv.ParseInt(binding.HostPort, 10, 64)
+			if err != nil {
+				continue
+			}
+
+			mapping := PortMappingModel{
+				ContainerPort: types.Int64Value(containerPort),
+				HostPort:      types.Int64Value(hostPort),
+				ListenAddress: types.StringNull(),
+				Protocol:      types.StringNull(),
+			}
+			if binding.HostIP != "" && binding.HostIP != defaultListen {
+				mapping.ListenAddress = types.StringValue(binding.HostIP)
+			}
+			if protocol = strings.ToUpper(protocol); protocol != "" && protocol != string(v1alpha4.PortMappingProtocolTCP) {
+				mapping.Protocol = types.StringValue(protocol)
+			}
+			mappings = append(mappings, mapping)
+		}
+	}
+
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].ContainerPort.ValueInt64() != mappings[j].ContainerPort.ValueInt64() {
+			return mappings[i].ContainerPort.ValueInt64() < mappings[j].ContainerPort.ValueInt64()
+		}
+		return mappings[i].Protocol.ValueString() < mappings[j].Protocol.ValueString()
+	})
+
+	return mappings
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestImportedMounts(t *testing.T) {
+	binds := []string{
+		"/lib/modules:/lib/modules:ro",
+		"/tmp/cluster-files:" + nodeFilesDir + ":ro",
+		"/srv/data:/data",
+		"/srv/config:/config:ro,Z",
+		"/srv/shared:/shared:rshared",
+		"/srv/logs:/logs:rslave",
+		"malformed",
+	}
+
+	want := []MountModel{
+		{HostPath: types.StringValue("/srv/data"), ContainerPath: types.StringValue("/data"), ReadOnly: types.BoolNull(), SelinuxRelabel: types.BoolNull(), Propagation: types.StringNull()},
+		{HostPath: types.StringValue("/srv/config"), ContainerPath: types.StringValue("/config"), ReadOnly: types.BoolValue(true), SelinuxRelabel: types.BoolValue(true), Propagation: types.StringNull()},
+		{HostPath: types.StringValue("/srv/shared"), ContainerPath: types.StringValue("/shared"), ReadOnly: types.BoolNull(), SelinuxRelabel: types.BoolNull(), Propagation: types.StringValue("Bidirectional")},
+		{HostPath: types.StringValue("/srv/logs"), ContainerPath: types.StringValue("/logs"), ReadOnly: types.BoolNull(), SelinuxRelabel: types.BoolNull(), Propagation: types.StringValue("HostToContainer")},
+	}
+
+	if got := importedMounts(binds); !slices.Equal(got, want) {
+		t.Errorf("importedMounts() = %+v, want %+v", got, want)
+	}
+	if got := importedMounts(nil); got != nil {
+		t.Errorf("importedMounts(nil) = %+v, want nil", got)
+	}
+}
+
+func TestImportedPortMappings(t *testing.T) {
+	inspected := &containerInspect{}
+	if err := json.Unmarshal([]byte(`{
+  "HostConfig": {
+    "PortBindings": {
+      "6443/tcp": [{"HostIp": "192.168.1.10", "HostPort": "41234"}],
+      "443/tcp": [{"HostIp": "0.0.0.0", "HostPort": "8443"}],
+      "80/tcp": [{"HostIp": "127.0.0.1", "HostPort": "8080"}],
+      "53/udp": [{"HostIp": "", "HostPort": "5353"}],
+      "9000/tcp": [{"HostIp": "0.0.0.0", "HostPort": ""}]
+    }
+  }
+}`), inspected); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		controlPlane     bool
+		ipFamily         types.String
+		want             []PortMappingModel
+		apiServerAddress types.String
+	}{
+		{
+			name:         "control plane",
+			controlPlane: true,
+			ipFamily:     types.StringNull(),
+			want: []PortMappingModel{
+				{ContainerPort: types.Int64Value(53), HostPort: types.Int64Value(5353), ListenAddress: types.StringNull(), Protocol: types.StringValue("UDP")},
+				{ContainerPort: types.Int64Value(80), HostPort: types.Int64Value(8080), ListenAddress: types.StringValue("127.0.0.1"), Protocol: types.StringNull()},
+				{ContainerPort: types.Int64Value(443), HostPort: types.Int64Value(8443), ListenAddress: types.StringNull(), Protocol: types.StringNull()},
+			},
+			apiServerAddress: types.StringValue("192.168.1.10"),
+		},
+		{
+			name:         "worker keeps the API server port",
+			controlPlane: false,
+			ipFamily:     types.StringNull(),
+			want: []PortMappingModel{
+				{ContainerPort: types.Int64Value(53), HostPort: types.Int64Value(5353), ListenAddress: types.StringNull(), Protocol: types.StringValue("UDP")},
+				{ContainerPort: types.Int64Value(80), HostPort: types.Int64Value(8080), ListenAddress: types.StringValue("127.0.0.1"), Protocol: types.StringNull()},
+				{ContainerPort: types.Int64Value(443), HostPort: types.Int64Value(8443), ListenAddress: types.StringNull(), Protocol: types.StringNull()},
+				{ContainerPort: types.Int64Value(6443), HostPort: types.Int64Value(41234), ListenAddress: types.StringValue("192.168.1.10"), Protocol: types.StringNull()},
+			},
+			apiServerAddress: types.StringNull(),
+		},
+		{
+			name:         "ipv6 default listen address",
+			controlPlane: true,
+			ipFamily:     types.StringValue("ipv6"),
+			want: []PortMappingModel{
+				{ContainerPort: types.Int64Value(53), HostPort: types.Int64Value(5353), ListenAddress: types.StringNull(), Protocol: types.StringValue("UDP")},
+				{ContainerPort: types.Int64Value(80), HostPort: types.Int64Value(8080), ListenAddress: types.StringValue("127.0.0.1"), Protocol: types.StringNull()},
+				{ContainerPort: types.Int64Value(443), HostPort: types.Int64Value(8443), ListenAddress: types.StringValue("0.0.0.0"), Protocol: types.StringNull()},
+			},
+			apiServerAddress: types.StringValue("192.168.1.10"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			networking := &NetworkingModel{IPFamily: tt.ipFamily, APIServerAddress: types.StringNull()}
+
+			if got := importedPortMappings(inspected, tt.controlPlane, networking); !slices.Equal(got, tt.want) {
+				t.Errorf("importedPortMappings() = %+v, want %+v", got, tt.want)
+			}
+			if networking.APIServerAddress != tt.apiServerAddress {
+				t.Errorf("api_server_address = %s, want %s", networking.APIServerAddress, tt.apiServerAddress)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+)
+
+// exportClusterLogs collects the logs of a cluster whose creation failed into
+// dir, or into a new temporary directory when dir is empty, and returns a
+// sentence telling the user where they are for the failure diagnostic.
+func (r *ClusterResource) exportClusterLogs(clusterName, dir string) string {
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "kind-logs-"+clusterName+"-")
+		if err != nil {
+			return fmt.Sprintf("Exporting cluster logs failed: %s", err)
+		}
+		dir = tmp
+	}
+
+	if err := r.provider.CollectLogs(clusterName, dir); err != nil {
+		return fmt.Sprintf("Exporting cluster logs to %s failed: %s", dir, err)
+	}
+
+	return fmt.Sprintf("Cluster logs were exported to %s.", dir)
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+var _ datasource.DataSource = &ClusterNodesDataSource{}
+
+type ClusterNodesDataSource struct {
+	provider *cluster.Provider
+	runtime  string
+}
+
+func NewClusterNodesDataSource() datasource.DataSource {
+	return &ClusterNodesDataSource{}
+}
+
+func (d *ClusterNodesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_nodes"
+}
+
+func (d *ClusterNodesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the node containers of a KinD cluster with their roles and addresses on the cluster's container network. A cluster that does not exist has no nodes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (same as name).",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the cluster.",
+				Required:    true,
+			},
+			"nodes": schema.ListNestedAttribute{
+				Description: "The cluster's node containers sorted by name, including the external load balancer of multi control-plane clusters.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Container name, which is also the Kubernetes node name.",
+							Computed:    true,
+						},
+						"role": schema.StringAttribute{
+							Description: "Node role: control-plane, worker or external-load-balancer.",
+							Computed:    true,
+						},
+						"container_id": schema.StringAttribute{
+							Description: "Full ID of the node container.",
+							Computed:    true,
+						},
+						"internal_ip": schema.StringAttribute{
+							Description: "IPv4 address of the node on the cluster's container network, or its IPv6 address in IPv6 clusters.",
+							Computed:    true,
+						},
+						"internal_ipv6": schema.StringAttribute{
+							Description: "IPv6 address of the node on the cluster's container network, empty when it has none.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ClusterNodesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KindProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *KindProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.provider = providerData.Provider
+	d.runtime = providerData.Runtime
+}
+
+func (d *ClusterNodesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterNodesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterName := data.Name.ValueString()
+
+	// Nodes are found by their cluster label, so an unknown cluster yields an
+	// empty list rather than an error.
+	nodeList, err := d.provider.ListNodes(clusterName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list cluster nodes", err.Error())
+		return
+	}
+
+	nodes := make([]ClusterNodeDataModel, 0, len(nodeList))
+	for _, node := range nodeList {
+		name := node.String()
+
+		role, err := node.Role()
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read node", fmt.Sprintf("Could not get the role of %s: %s", name, err))
+			return
+		}
+
+		ipv4, ipv6, err := node.IP()
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read node", fmt.Sprintf("Could not get the address of %s: %s", name, err))
+			return
+		}
+		internalIP := ipv4
+		if internalIP == "" {
+			internalIP = ipv6
+		}
+
+		inspected, err := inspectContainer(ctx, d.runtime, name)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read node", err.Error())
+			return
+		}
+
+		nodes = append(nodes, ClusterNodeDataModel{
+			Name:         types.StringValue(name),
+			Role:         types.StringValue(role),
+			ContainerID:  types.StringValue(inspected.ID),
+			InternalIP:   types.StringValue(internalIP),
+			InternalIPv6: types.StringValue(ipv6),
+		})
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name.ValueString() < nodes[j].Name.ValueString()
+	})
+
+	data.ID = data.Name
+	data.Nodes = nodes
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
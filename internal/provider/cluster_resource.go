@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"math"
 	"os"
-	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -13,137 +16,46 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 	"sigs.k8s.io/kind/pkg/cluster"
-	"sigs.k8s.io/yaml"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
 )
 
 var (
-	_ resource.Resource                = &ClusterResource{}
-	_ resource.ResourceWithImportState = &ClusterResource{}
+	_ resource.Resource                   = &ClusterResource{}
+	_ resource.ResourceWithImportState    = &ClusterResource{}
+	_ resource.ResourceWithValidateConfig = &ClusterResource{}
 )
 
 type ClusterResource struct {
-	provider *cluster.Provider
+	provider    *cluster.Provider
+	runtime     string
+	metricsFile string
+	// diagnosticsFile receives a JSON line per cluster operation when set.
+	diagnosticsFile string
+	// defaultNodeImage is the provider's default_node_image.
+	defaultNodeImage string
 }
 
 func NewClusterResource() resource.Resource {
 	return &ClusterResource{}
 }
 
-// cleanupStaleLockFile removes stale kubeconfig lock files that may be left over
-// from interrupted operations. Only removes locks older than 60 seconds.
-func cleanupStaleLockFile() {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return
-	}
-
-	lockFile := filepath.Join(homeDir, ".kube", "config.lock")
-	info, err := os.Stat(lockFile)
-	if err != nil {
-		return // Lock file doesn't exist
-	}
-
-	// Only remove if older than 60 seconds (stale)
-	if time.Since(info.ModTime()) > 60*time.Second {
-		os.Remove(lockFile)
-	}
-}
-
-// waitForAllNodesReady waits for all nodes in the cluster to be in Ready state.
-// It uses the kubeconfig to connect to the cluster and polls node status.
-func waitForAllNodesReady(ctx context.Context, kubeconfigContent string, timeout time.Duration) error {
-	// Create a temporary kubeconfig file for the client
-	tmpFile, err := os.CreateTemp("", "kubeconfig-*.yaml")
-	if err != nil {
-		return fmt.Errorf("failed to create temp kubeconfig: %w", err)
-	}
-	defer os.Remove(tmpFile.Name())
-
-	if _, err := tmpFile.WriteString(kubeconfigContent); err != nil {
-		return fmt.Errorf("failed to write kubeconfig: %w", err)
-	}
-	if err := tmpFile.Close(); err != nil {
-		return fmt.Errorf("failed to close kubeconfig file: %w", err)
-	}
-
-	// Build kubernetes client from kubeconfig
-	config, err := clientcmd.BuildConfigFromFlags("", tmpFile.Name())
-	if err != nil {
-		return fmt.Errorf("failed to build kubeconfig: %w", err)
-	}
-
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return fmt.Errorf("failed to create kubernetes client: %w", err)
-	}
-
-	// Poll until all nodes are ready or timeout
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	timeoutCh := time.After(timeout)
-
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-timeoutCh:
-			return fmt.Errorf("timeout waiting for nodes to be ready after %v", timeout)
-		case <-ticker.C:
-			nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
-			if err != nil {
-				// Cluster might not be fully ready yet, continue polling
-				continue
-			}
-
-			if len(nodes.Items) == 0 {
-				// No nodes yet, continue polling
-				continue
-			}
-
-			allReady := true
-			notReadyNodes := []string{}
-			for _, node := range nodes.Items {
-				nodeReady := false
-				for _, condition := range node.Status.Conditions {
-					if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
-						nodeReady = true
-						break
-					}
-				}
-				if !nodeReady {
-					allReady = false
-					notReadyNodes = append(notReadyNodes, node.Name)
-				}
-			}
-
-			if allReady {
-				return nil
-			}
-			// Continue polling - some nodes are not ready yet
-		}
-	}
-}
-
 func (r *ClusterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_cluster"
 }
 
-func (r *ClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *ClusterResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a KinD (Kubernetes in Docker) cluster.",
 		Attributes: map[string]schema.Attribute{
@@ -161,97 +73,1108 @@ func (r *ClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"node_image": schema.StringAttribute{
-				Description: "The node image to use for the cluster nodes. Applies to all nodes unless overridden per node.",
-				Optional:    true,
-				Computed:    true,
-				Default:     stringdefault.StaticString(""),
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+			"node_image": schema.StringAttribute{
+				Description: "The node image to use for the cluster nodes. Applies to all nodes unless overridden per node. When unset, it is set to the image KinD chose, as reported by the node containers.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"node_image_digest": schema.StringAttribute{
+				Description: "Expected content digest of the node image, e.g. sha256:0123...: node_image, else the provider's default_node_image, else KinD's default. Before the nodes are created the image is pulled if needed and its repository digest compared, failing the create when a moved tag resolves to different content. Per-node image overrides are not checked.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_ready": schema.Int64Attribute{
+				Description: "Time in seconds to wait for the control plane to be ready. Default is 300 (5 minutes).",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(300),
+			},
+			"wait_for_deployments": schema.ListAttribute{
+				Description: "Deployments, as namespace/name, to wait for after the readiness wait until all their replicas are updated and available, e.g. kube-system/coredns. Uses the wait_for_ready timeout. Useful to wait for a CNI installed by other means when disable_default_cni is set.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					namespacedNameValidator{},
+				},
+			},
+			"wait_for_nodes_ready": schema.BoolAttribute{
+				Description: "Wait for all nodes (including workers) to be in Ready state after cluster creation. Uses the wait_for_ready timeout. Default is true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"wait_for_api_only": schema.BoolAttribute{
+				Description: "Instead of waiting for node readiness, poll the API server /healthz and /readyz endpoints until they return ok within the wait_for_ready timeout. Use for clusters with disable_default_cni, whose nodes stay NotReady until a CNI is installed. Takes precedence over wait_for_nodes_ready. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"revalidate_after_update": schema.BoolAttribute{
+				Description: "Re-run the readiness wait (wait_for_api_only or wait_for_nodes_ready) at the end of an in-place update, failing the apply if the cluster is no longer healthy. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"wait_kubeconfig_override": schema.StringAttribute{
+				Description: "Kubeconfig content the provider uses to reach the cluster API for readiness waits and post-create steps, instead of the generated kubeconfig. Needed with remote Docker hosts where the generated endpoint is not reachable from the Terraform host and has to be rewritten.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					kubeconfigValidator{},
+				},
+			},
+			"loaded_images": schema.ListAttribute{
+				Description: "Local container images to load into every node after creation, like `kind load docker-image`. Images added later are loaded in place without recreating the cluster; removing an image from the list does not unload it.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"restart_workloads": schema.ListAttribute{
+				Description: "Workloads to roll after images or archives added to loaded_images or image_archives are loaded in place, as namespace/kind/name with kind deployment, statefulset or daemonset, e.g. default/deployment/web. The restart works like `kubectl rollout restart`, so pods start again from the freshly loaded images. Every target must exist. Not used on create, where no workloads run yet.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"image_archives": schema.ListAttribute{
+				Description: "Paths to image archives created with `docker save` to load into every node after creation, like `kind load image-archive`. Paths known at plan time must be readable archives. Archives added later are loaded in place; removing one does not unload its images.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"export_bundle_path": schema.StringAttribute{
+				Description: "Directory to write a portable bundle to once the cluster is ready: the rendered KinD config (kind-config.yaml), the kubeconfig and the node and loaded images (images.txt), so the cluster can be reproduced on another machine. The bundle is rewritten on every update; changing the path moves it without recreating the cluster. The bundle files are removed on destroy.",
+				Optional:    true,
+			},
+			"check_host_limits": schema.BoolAttribute{
+				Description: "Fail before creating the cluster when the host inotify and file descriptor limits are below what the node count needs, with the sysctl values to raise. Limits above these minimums but below KinD's recommendations are reported as a warning. Skipped for remote Docker hosts. Default is true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"skip_preflight": schema.BoolAttribute{
+				Description: "Skip the host checks run before creating the cluster: the check_host_limits minimums, the warning for inotify limits below KinD's recommendations or file handles close to running out, and the subnet overlap check. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"require_no_subnet_overlap": schema.BoolAttribute{
+				Description: "Fail before creating the cluster when its pod or service subnet, including KinD's defaults, overlaps a host interface network or a container runtime network. Overlaps are reported as warnings otherwise. Host interfaces are skipped for remote Docker hosts. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"kubeconfig_output_path": schema.StringAttribute{
+				Description: "Path to write the kubeconfig to, reported in kubeconfig_path. ~ and relative paths are expanded, parent directories are created and the file is written with mode 0600. It is rewritten on every refresh and removed on destroy. An existing file holding anything but this cluster's context, such as ~/.kube/config, is never overwritten or removed; use merge_kubeconfig for shared kubeconfigs. Defaults to ~/.kube/kind/kind-<name>.",
+				Optional:    true,
+			},
+			"kubeconfig_context_name": schema.StringAttribute{
+				Description: "Name of the context, cluster and user in the kubeconfig attribute, the file at kubeconfig_path, the export bundle and, with merge_kubeconfig, the default kubeconfig, instead of KinD's kind-<name>. The credentials are unchanged. Changes are applied in place.",
+				Optional:    true,
+			},
+			"export_kubeconfig_on_read": schema.BoolAttribute{
+				Description: "Fetch the kubeconfig again on every refresh, so kubeconfig, the credential attributes and the written files follow certificate rotation. They are only updated when the content changed. When false, the kubeconfig fetched at create or update is kept. Default is true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"merge_kubeconfig": schema.BoolAttribute{
+				Description: "Merge the cluster's context into the default kubeconfig (KUBECONFIG or ~/.kube/config) so kubectl works immediately, and remove it again on destroy. Otherwise the kubeconfig is only written to kubeconfig_path. Changes are applied in place. Default is true, as KinD itself does.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"cni_manifest": schema.StringAttribute{
+				Description: "CNI manifest, as an http(s) URL or a local file path, applied with server-side apply after creation, usually with networking.disable_default_cni. The DaemonSets and Deployments it creates must be ready within wait_for_ready before the node readiness wait starts. Changing the value re-applies the manifest in place; removing it does not uninstall the CNI, and changes to the content behind an unchanged value are not detected.",
+				Optional:    true,
+			},
+			"apply_manifests": schema.ListAttribute{
+				Description: "Manifests applied with server-side apply once the nodes are ready, after the other post-create steps. Each element is a file path, or inline YAML when it spans several lines or is a JSON object, and may hold several documents. Documents are applied in order and each failure is reported separately. Changing the list re-applies it and deletes the objects no longer in it.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"delete_manifests_on_destroy": schema.BoolAttribute{
+				Description: "Delete the objects from apply_manifests, in reverse order, before deleting the cluster, so controllers can clean up resources outside it. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"auto_approve_kubelet_certs": schema.BoolAttribute{
+				Description: "Have the kubelets request serving certificates signed by the cluster CA (serverTLSBootstrap) and approve the requests after creation and when workers are added, so metrics-server and other clients of the kubelet API can verify it without --kubelet-insecure-tls. Only requests made with a node's own kubelet credentials are approved. Renewals requested later are not approved. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"install_node_local_dns": schema.BoolAttribute{
+				Description: "Install NodeLocal DNSCache once the nodes are ready and wait, within wait_for_ready, for it to run on every node. Pods keep resolving through the kube-dns service IP, which the cache intercepts, except with kube_proxy_mode ipvs, where the kubelet points pods at " + nodeLocalDNSIP + ". Requires kube_proxy_mode iptables or ipvs and an IPv4 or dual-stack cluster. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"install_cert_manager": schema.BoolAttribute{
+				Description: "Install cert-manager once the cluster is ready and wait, within wait_for_ready, for its webhook to be available. Enabling it or changing cert_manager_version later applies the manifests in place; disabling it does not uninstall cert-manager. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"cert_manager_version": schema.StringAttribute{
+				Description: "cert-manager release installed by install_cert_manager, e.g. v1.19.1. Defaults to " + defaultCertManagerVersion + ".",
+				Optional:    true,
+				Validators: []validator.String{
+					releaseVersionValidator{},
+				},
+			},
+			"create_retries": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of times to retry a failed cluster creation, with exponential backoff starting at %s. The partially created cluster is deleted before each retry. An existing cluster of the same name, an invalid configuration or an image that cannot be pulled fail right away. Default is %d.", createRetryBaseDelay, defaultCreateRetries),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultCreateRetries),
+				Validators: []validator.Int64{
+					int64AtLeastValidator{min: 0},
+				},
+			},
+			"manifest_apply_retries": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of times to retry a failed apply of the objects created after the cluster is up: cert-manager, RBAC manifests, namespace policies, priority and runtime classes and the CA bundle ConfigMaps. Objects the API server rejects as invalid are not retried. Default is %d.", defaultManifestApplyRetries),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultManifestApplyRetries),
+				Validators: []validator.Int64{
+					int64AtLeastValidator{min: 1},
+				},
+			},
+			"manifest_apply_timeout": schema.Int64Attribute{
+				Description: fmt.Sprintf("Time in seconds each apply attempt of manifest_apply_retries may take. Default is %d.", defaultManifestApplyTimeout),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultManifestApplyTimeout),
+				Validators: []validator.Int64{
+					int64AtLeastValidator{min: 1},
+				},
+			},
+			"export_logs_on_failure": schema.BoolAttribute{
+				Description: "Collect the node logs, like `kind export logs`, when cluster creation fails, and include their location in the error. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"stop_before_kubernetes": schema.BoolAttribute{
+				Description: "Create the node containers but stop before KinD sets up Kubernetes, for driving kubeadm by hand. The cluster is not functional until the bootstrap is finished inside the nodes. kubeconfig and the credential attributes stay empty, and every step needing the API server, such as readiness waits, CNI and manifests, is skipped. Changes recreate the cluster. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt a KinD cluster of the same name that already exists when creating, instead of failing, typically one left behind by an interrupted apply. Creation and the node settings applied right after it are skipped; the remaining post-create steps run against the existing cluster. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"retain_on_failure": schema.BoolAttribute{
+				Description: "Keep the node containers of a cluster that failed to come up running for inspection instead of deleting them. Only the final attempt is retained when create_retries is set. The retained cluster is not tracked in state and must be deleted with `kind delete cluster` before applying again. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"log_export_path": schema.StringAttribute{
+				Description: "Directory export_logs_on_failure writes the logs to. Defaults to a new temporary directory.",
+				Optional:    true,
+			},
+			"feature_gates": schema.MapAttribute{
+				Description: "Kubernetes feature gates to enable/disable. Map of feature gate name to boolean.",
+				Optional:    true,
+				ElementType: types.BoolType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"runtime_config": schema.MapAttribute{
+				Description: "Runtime configuration for kube-apiserver (--runtime-config flags). Used to enable alpha APIs.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"enable_apis": schema.ListAttribute{
+				Description: "APIs to enable or disable in kube-apiserver, compiled into runtime_config. Entries are group/version[=true|false], e.g. flowcontrol.apiserver.k8s.io/v1beta3 or batch/v2alpha1=false, or api/all, api/ga, api/beta, api/alpha. A bare group/version enables it. runtime_config entries take precedence.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"fail_swap_on": schema.BoolAttribute{
+				Description: "Kubelet failSwapOn setting. KinD already disables it so nodes can start on hosts with swap enabled; set to true to make kubelet refuse to start when swap is on. Only relevant on hosts with swap.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"kubelet_system_reserved": schema.MapAttribute{
+				Description: "Resources reserved for system daemons on every node (kubelet systemReserved). Keys: cpu, memory, ephemeral-storage, pid.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					resourceListValidator{allowed: reservedResourceNames},
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"kubelet_kube_reserved": schema.MapAttribute{
+				Description: "Resources reserved for Kubernetes system components on every node (kubelet kubeReserved). Keys: cpu, memory, ephemeral-storage, pid.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					resourceListValidator{allowed: reservedResourceNames},
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"serialize_image_pulls": schema.BoolAttribute{
+				Description: "Kubelet serializeImagePulls setting on every node. Set to false to pull images in parallel.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"registry_pull_qps": schema.Int64Attribute{
+				Description: "Kubelet registryPullQPS on every node: image pulls per second, 0 for no limit.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64BetweenValidator{min: 0, max: math.MaxInt32},
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"registry_burst": schema.Int64Attribute{
+				Description: "Kubelet registryBurst on every node: image pulls allowed in a burst above registry_pull_qps.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64BetweenValidator{min: 0, max: math.MaxInt32},
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"audit_policy_preset": schema.StringAttribute{
+				Description: "Enable API server audit logging with a built-in policy: minimal (metadata of write requests), metadata, request, or request_response. Secrets and ConfigMaps are always logged at metadata level. Logs are written to " + auditLogDir + " on the control-plane nodes.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: auditPolicyPresetNames},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"audit_policy": schema.StringAttribute{
+				Description: "Enable API server audit logging with an explicit audit Policy (YAML). Overrides audit_policy_preset.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"watch_cache_sizes": schema.MapAttribute{
+				Description: "API server watch cache size per resource (--watch-cache-sizes). Keys are resource names such as pods or deployments.apps; 0 disables the cache for that resource.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.Map{
+					int64AtLeastValidator{min: 0},
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"default_watch_cache_size": schema.Int64Attribute{
+				Description: "Default API server watch cache size (--default-watch-cache-size). 0 disables the watch cache for resources without an explicit size.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64AtLeastValidator{min: 0},
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"api_server_shutdown_delay_duration": schema.StringAttribute{
+				Description: "Time the API server keeps serving after receiving SIGTERM while reporting not ready (--shutdown-delay-duration), e.g. 10s. Lets load balancers drain it before it stops.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"api_server_shutdown_watch_termination_grace_period": schema.StringAttribute{
+				Description: "Grace period for active watch requests to drain during API server shutdown (--shutdown-watch-termination-grace-period), e.g. 15s.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"api_server_shutdown_send_retry_after": schema.BoolAttribute{
+				Description: "Respond to new requests with a Retry-After during API server shutdown instead of accepting them (--shutdown-send-retry-after).",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"goaway_chance": schema.Float64Attribute{
+				Description: "Probability, from 0 to 0.02, that the API server answers an HTTP/2 request with GOAWAY so the client reconnects, possibly through another load balancer backend (--goaway-chance). Rebalances long-lived connections in HA clusters. Kubernetes defaults to 0, disabled.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.RequiresReplace(),
+				},
+			},
+			"apiserver_extra_args": schema.MapAttribute{
+				Description: "API server flags without the leading --, e.g. oidc-issuer-url or audit-log-maxage, rendered into the kubeadm ClusterConfiguration apiServer.extraArgs. Entries override flags the provider derives from other attributes. Changes recreate the cluster.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"hpa_sync_period": schema.StringAttribute{
+				Description: "How often the controller manager reconciles HorizontalPodAutoscalers (--horizontal-pod-autoscaler-sync-period), e.g. 5s. Kubernetes defaults to 15s.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"terminated_pod_gc_threshold": schema.Int64Attribute{
+				Description: "Number of terminated pods kept before the pod garbage collector deletes them (--terminated-pod-gc-threshold). Kubernetes defaults to 12500.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64AtLeastValidator{min: 1},
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"kubeadm_config_patches": schema.ListAttribute{
+				Description: "Kubeadm config patches (RFC 7386 merge patches) applied to all nodes. Appending ClusterConfiguration patches that only set apiServer, controllerManager or scheduler is applied in place by regenerating the control-plane static pods; any other change, including apiServer.certSANs, which needs a new serving certificate, or editing or removing a patch, replaces the cluster.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					kubeadmPatchesPlanModifier{},
+				},
+			},
+			"containerd_config_patches": schema.ListAttribute{
+				Description: "Containerd config patches (TOML format) applied to all nodes.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"containerd_metrics_address": schema.StringAttribute{
+				Description: "Address (host:port) for the containerd metrics endpoint on each node, e.g. 0.0.0.0:1338. The endpoint exposes node-level metrics reachable from the kind Docker network.",
+				Optional:    true,
+				Validators: []validator.String{
+					hostPortValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cri_device_ownership_from_security_context": schema.BoolAttribute{
+				Description: "Set the containerd CRI device_ownership_from_security_context option on every node, so devices passed to a container, e.g. by a device plugin, are owned by the pod's runAsUser and runAsGroup instead of root. Needed to test device plugins or GPU workloads running as non-root. containerd defaults to false.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"enable_image_cache_passthrough": schema.BoolAttribute{
+				Description: "Mount a content store shared by all clusters on this host (~/.kube/kind/image-cache) into every node, so image layers pulled by one cluster are reused by others. " +
+					"Containerd garbage collection is disabled on the nodes and the store is never pruned by the provider. Image loads into such clusters take a host-wide lock (~/.kube/kind/image-cache.lock), so concurrent applies import one at a time; concurrent kubelet pulls of the same layer are not covered and may fail digest verification and be retried. " +
+					"Kubelet image GC thresholds cannot be set with this option. Default is false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"registry_certs": schema.MapAttribute{
+				Description: "CA certificates (PEM) for registries served with custom TLS, keyed by registry host[:port]. Each is written to /etc/containerd/certs.d/<host>/ca.crt on every node after creation and containerd is restarted.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					registryCertsValidator{},
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"node_timezone": schema.StringAttribute{
+				Description: "IANA timezone (e.g. Europe/Berlin) set as /etc/localtime inside every node container after creation. Changes are applied in place; removing it restores Etc/UTC. Affects node processes such as the kubelet and container runtime only, not the host or pods, which keep the timezone of their images.",
+				Optional:    true,
+				Validators: []validator.String{
+					timezoneValidator{},
+				},
+			},
+			"containerd_config_patches_json6902": schema.ListAttribute{
+				Description: "Containerd config patches (RFC 6902 JSON patches) applied to all nodes.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"kubeconfig": schema.StringAttribute{
+				Description: "The kubeconfig content for connecting to the cluster.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"kubeconfig_path": schema.StringAttribute{
+				Description: "The path to the kubeconfig file.",
+				Computed:    true,
+			},
+			"client_certificate": schema.StringAttribute{
+				Description: "Base64 encoded client certificate for TLS authentication.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "Base64 encoded client key for TLS authentication.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Description: "Base64 encoded cluster CA certificate.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"endpoint": schema.StringAttribute{
+				Description: "The Kubernetes API server endpoint.",
+				Computed:    true,
+			},
+			"kubernetes_connection": schema.SingleNestedAttribute{
+				Description: "Connection details in the shape the hashicorp/kubernetes and hashicorp/helm providers expect. Certificates and key are PEM encoded.",
+				Computed:    true,
+				Sensitive:   true,
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Description: "The Kubernetes API server endpoint.",
+						Computed:    true,
+					},
+					"cluster_ca_certificate": schema.StringAttribute{
+						Description: "PEM encoded cluster CA certificate.",
+						Computed:    true,
+					},
+					"client_certificate": schema.StringAttribute{
+						Description: "PEM encoded client certificate.",
+						Computed:    true,
+					},
+					"client_key": schema.StringAttribute{
+						Description: "PEM encoded client key.",
+						Computed:    true,
+					},
+				},
+			},
+			"load_balancer_endpoint": schema.StringAttribute{
+				Description: "Host URL of the HAProxy load balancer KinD creates in front of the API servers when more than one control-plane node is configured, e.g. https://127.0.0.1:41235. Null for a single control plane.",
+				Computed:    true,
+			},
+			"topology_summary": schema.StringAttribute{
+				Description: "Human-readable description of the cluster for docs and PR comments: node counts by role, IP family and CIDRs with KinD's defaults filled in, kube-proxy mode, CNI and notable enabled features. Derived from the configuration and node list only.",
+				Computed:    true,
+			},
+			"kubernetes_version": schema.StringAttribute{
+				Description: "Kubernetes version reported by the API server, e.g. v1.35.0, for checking what the node image actually runs. Null when the API server could not be reached.",
+				Computed:    true,
+			},
+			"applied_manifests": schema.ListNestedAttribute{
+				Description: "Objects applied from apply_manifests, in apply order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"api_version": schema.StringAttribute{
+							Description: "API version of the object.",
+							Computed:    true,
+						},
+						"kind": schema.StringAttribute{
+							Description: "Kind of the object.",
+							Computed:    true,
+						},
+						"namespace": schema.StringAttribute{
+							Description: "Namespace of the object. Empty for cluster-scoped objects.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the object.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"node_names": schema.ListNestedAttribute{
+				Description: "Node containers of the cluster, sorted by container name, with their IDs, for referencing them in container data sources or exec commands.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"container_name": schema.StringAttribute{
+							Description: "Name of the node container, e.g. kind-control-plane.",
+							Computed:    true,
+						},
+						"container_id": schema.StringAttribute{
+							Description: "Full ID of the node container, as reported by the container runtime.",
+							Computed:    true,
+						},
+						"role": schema.StringAttribute{
+							Description: "Node role: control-plane, worker or external-load-balancer.",
+							Computed:    true,
+						},
+						"kubernetes_node_name": schema.StringAttribute{
+							Description: "Name of the Kubernetes Node object. Empty for the external load balancer, which is not a Kubernetes node.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"node_os_info": schema.MapNestedAttribute{
+				Description: "Operating system details reported by each node, keyed by node name. Read from the node status on a best-effort basis.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"os_image": schema.StringAttribute{
+							Description: "OS image reported by the node.",
+							Computed:    true,
+						},
+						"kernel_version": schema.StringAttribute{
+							Description: "Kernel version reported by the node.",
+							Computed:    true,
+						},
+						"container_runtime_version": schema.StringAttribute{
+							Description: "Container runtime version reported by the node.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"total_capacity_cpu": schema.StringAttribute{
+				Description: "Total CPU capacity summed across all nodes, as a Kubernetes quantity. Read on a best-effort basis.",
+				Computed:    true,
+			},
+			"total_capacity_memory": schema.StringAttribute{
+				Description: "Total memory capacity summed across all nodes, as a Kubernetes quantity. Read on a best-effort basis.",
+				Computed:    true,
+			},
+			"total_allocatable_cpu": schema.StringAttribute{
+				Description: "Total allocatable CPU summed across all nodes, as a Kubernetes quantity. Read on a best-effort basis.",
+				Computed:    true,
+			},
+			"total_allocatable_memory": schema.StringAttribute{
+				Description: "Total allocatable memory summed across all nodes, as a Kubernetes quantity. Read on a best-effort basis.",
+				Computed:    true,
+			},
+			"allocated_pod_ips": schema.Int64Attribute{
+				Description: "Number of pod IPs allocated from the pod subnet (host-network pods excluded). Read on a best-effort basis.",
+				Computed:    true,
+			},
+			"allocated_service_ips": schema.Int64Attribute{
+				Description: "Number of cluster IPs allocated from the service subnet. Read on a best-effort basis.",
+				Computed:    true,
+			},
+			"detected_ip_family": schema.StringAttribute{
+				Description: "IP family the cluster actually came up with (ipv4, ipv6 or dual), detected from pod IPs. Falls back to the configured family with a warning when detection fails.",
+				Computed:    true,
+			},
+			"node_taints": schema.MapAttribute{
+				Description: "Taints of each node, keyed by node name, as sorted key[=value]:Effect strings. Read on a best-effort basis.",
+				Computed:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+			},
+			"namespaces": schema.ListAttribute{
+				Description: "Names of the namespaces in the cluster, sorted. Read on a best-effort basis.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"kubeadm_config": schema.StringAttribute{
+				Description: "ClusterConfiguration YAML kubeadm recorded in the kube-system/kubeadm-config ConfigMap, the effective configuration after all patches. Read on a best-effort basis.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"default_runtime_class": schema.SingleNestedBlock{
+				Description: "RuntimeClass created after cluster creation, pointing at a containerd runtime handler. The handler must be built into the node image (runc, test-handler) or declared in containerd_config_patches.",
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "Name of the RuntimeClass.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"handler": schema.StringAttribute{
+						Description: "Containerd runtime handler the RuntimeClass refers to.",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"preferred": schema.BoolAttribute{
+						Description: "Also make the handler the default runtime of containerd on every node, so pods without a runtimeClassName run with it too. Set at creation through containerd_config_patches; pods can still opt out with the runc RuntimeClass handler. Default is false.",
+						Optional:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"registry_mirror": schema.ListNestedBlock{
+				Description: "Registry mirrors, written after creation as containerd hosts.toml files under " + registryCertsDir + "/<endpoint> on every node; the node images configure containerd to read registry hosts from there, which rules out the older registry.mirrors config section. Certificates from registry_certs for the registry or a mirror host are referenced automatically. containerd_config_patches still apply for anything else.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"endpoint": schema.StringAttribute{
+							Description: "Registry to mirror, as it appears in image names, e.g. docker.io or ghcr.io.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+						"mirrors": schema.ListAttribute{
+							Description: "Mirror URLs tried in order before the registry itself, e.g. http://registry-cache:5000.",
+							Required:    true,
+							ElementType: types.StringType,
+							PlanModifiers: []planmodifier.List{
+								listplanmodifier.RequiresReplace(),
+							},
+						},
+					},
+				},
+			},
+			"rbac": schema.SingleNestedBlock{
+				Description: "RBAC objects created once the cluster is ready, for repeatable authorization tests. Objects that already exist are updated.",
+				Attributes: map[string]schema.Attribute{
+					"manifests": schema.ListAttribute{
+						Description: "Inline YAML manifests of rbac.authorization.k8s.io/v1 ClusterRole, Role, ClusterRoleBinding and RoleBinding objects; a manifest may hold several documents. Roles are applied before bindings, otherwise in the configured order. Namespaced objects without a namespace go to default.",
+						Required:    true,
+						ElementType: types.StringType,
+						PlanModifiers: []planmodifier.List{
+							listplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"local_registry": schema.SingleNestedBlock{
+				Description: "Local image registry for the cluster, following KinD's local registry guide. After creation the registry container is started on the KinD network, or reused when it already exists, and published on 127.0.0.1:<port>. Pulls of localhost:<port>/... images are sent to it through a containerd hosts.toml on every node, and the local-registry-hosting ConfigMap in kube-public advertises it to tools. A container created with the cluster is removed with it; a reused one is left running for the clusters sharing it. Changes recreate the cluster.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "Name of the registry container, which nodes resolve on the KinD network. Defaults to " + defaultLocalRegistryName + ".",
+						Optional:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"port": schema.Int64Attribute{
+						Description: fmt.Sprintf("Host port the registry is published on, so images are pushed to localhost:<port>. Defaults to %d.", defaultLocalRegistryPort),
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64BetweenValidator{min: 1, max: 65535},
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.RequiresReplace(),
+						},
+					},
+					"image": schema.StringAttribute{
+						Description: "Registry image. Defaults to " + defaultLocalRegistryImage + ".",
+						Optional:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"cluster_ca_bundle": schema.SingleNestedBlock{
+				Description: "Shared test CA trusted across the cluster. After creation the bundle is added to every node's trust store (" + nodeCABundlePath + ", then update-ca-certificates and a containerd restart), so image pulls trust it, and published as a ConfigMap for workloads to mount, e.g. at /etc/ssl/certs.",
+				Attributes: map[string]schema.Attribute{
+					"pem": schema.StringAttribute{
+						Description: "PEM encoded CA certificates.",
+						Required:    true,
+						Validators: []validator.String{
+							pemCertificatesValidator{},
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"config_map_name": schema.StringAttribute{
+						Description: "Name of the ConfigMap holding the bundle under the key " + caBundleConfigMapKey + ". Defaults to " + defaultCABundleConfigMapName + ".",
+						Optional:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"namespaces": schema.ListAttribute{
+						Description: "Namespaces to create the ConfigMap in, created if missing. Defaults to default.",
+						Optional:    true,
+						ElementType: types.StringType,
+						PlanModifiers: []planmodifier.List{
+							listplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"coredns": schema.SingleNestedBlock{
+				Description: "CoreDNS settings patched into the coredns ConfigMap once the cluster is ready, after which CoreDNS is restarted. Changes are applied in place; removing the block leaves the Corefile as it is.",
+				Attributes: map[string]schema.Attribute{
+					"forward_to": schema.ListAttribute{
+						Description: "Upstream resolvers for the root zone, replacing /etc/resolv.conf. IP addresses with an optional port, optionally prefixed with dns:// or tls://.",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							dnsUpstreamValidator{},
+						},
+					},
+					"cache_ttl": schema.Int64Attribute{
+						Description: "Maximum TTL in seconds of cached responses.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64BetweenValidator{min: 1, max: 86400},
+						},
+					},
+				},
+			},
+			"kube_proxy_conntrack": schema.SingleNestedBlock{
+				Description: "Kube-proxy conntrack settings, for reproducing conntrack exhaustion in load tests.",
+				Attributes: map[string]schema.Attribute{
+					"max_per_core": schema.Int64Attribute{
+						Description: "Conntrack entries per CPU core. KinD sets 0 so kube-proxy leaves nf_conntrack_max alone; a non-zero value makes kube-proxy write the host-wide sysctl, which fails when /proc/sys is read-only in the node container.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64BetweenValidator{min: 0, max: math.MaxInt32},
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.RequiresReplace(),
+						},
+					},
+					"min": schema.Int64Attribute{
+						Description: "Minimum number of conntrack entries, regardless of max_per_core.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64BetweenValidator{min: 0, max: math.MaxInt32},
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.RequiresReplace(),
+						},
+					},
+					"tcp_established_timeout": schema.StringAttribute{
+						Description: "Idle timeout for established TCP connections, e.g. 24h.",
+						Optional:    true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"tcp_close_wait_timeout": schema.StringAttribute{
+						Description: "Timeout for TCP connections in CLOSE_WAIT, e.g. 1h.",
+						Optional:    true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"kubelet_log_rotation": schema.SingleNestedBlock{
+				Description: "Kubelet container log rotation and image garbage collection settings, keeping node disks from filling during long test runs.",
+				Attributes: map[string]schema.Attribute{
+					"container_log_max_size": schema.StringAttribute{
+						Description: "Maximum size of a container log file before it is rotated (e.g., 10Mi).",
+						Optional:    true,
+						Validators: []validator.String{
+							quantityValidator{},
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"container_log_max_files": schema.Int64Attribute{
+						Description: "Maximum number of log files kept per container. Kubelet requires at least 2.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64AtLeastValidator{min: 2},
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.RequiresReplace(),
+						},
+					},
+					"image_gc_high_threshold_percent": schema.Int64Attribute{
+						Description: "Disk usage percent that triggers image garbage collection. KinD sets 100, effectively disabling it.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64BetweenValidator{min: 0, max: 100},
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.RequiresReplace(),
+						},
+					},
+					"image_gc_low_threshold_percent": schema.Int64Attribute{
+						Description: "Disk usage percent image garbage collection frees down to. Must be lower than image_gc_high_threshold_percent.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64BetweenValidator{min: 0, max: 100},
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"graceful_node_shutdown": schema.SingleNestedBlock{
+				Description: "Kubelet graceful node shutdown: on node shutdown the kubelet holds a systemd-logind inhibitor lock and terminates pods, regular ones first and critical ones last, within the grace period. The kubelet raises logind's InhibitDelayMaxSec to the grace period itself; stopping the node container with a timeout above grace_period, or shutting it down from inside with systemctl poweroff, triggers the shutdown sequence.",
+				Attributes: map[string]schema.Attribute{
+					"grace_period": schema.StringAttribute{
+						Description: "Total time the node delays shutdown for pod termination (shutdownGracePeriod), e.g. 30s. Required for the feature to be active.",
+						Optional:    true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"critical_pods_grace_period": schema.StringAttribute{
+						Description: "Part of grace_period reserved for critical pods (shutdownGracePeriodCriticalPods), e.g. 10s. Must not exceed grace_period.",
+						Optional:    true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"namespace_policies": schema.ListNestedBlock{
+				Description: "Namespaces created after the nodes are ready, each with an optional ResourceQuota and container LimitRange. Existing objects are left in place.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"namespace": schema.StringAttribute{
+							Description: "Namespace to create and apply the policies to.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+						"resource_quota": schema.MapAttribute{
+							Description: "ResourceQuota hard limits, e.g. requests.cpu, limits.memory or pods, mapped to quantities.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								quantityValidator{},
+							},
+							PlanModifiers: []planmodifier.Map{
+								mapplanmodifier.RequiresReplace(),
+							},
+						},
+						"limit_default": schema.MapAttribute{
+							Description: "Default container limits set by the LimitRange.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								quantityValidator{},
+							},
+							PlanModifiers: []planmodifier.Map{
+								mapplanmodifier.RequiresReplace(),
+							},
+						},
+						"limit_default_request": schema.MapAttribute{
+							Description: "Default container requests set by the LimitRange.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								quantityValidator{},
+							},
+							PlanModifiers: []planmodifier.Map{
+								mapplanmodifier.RequiresReplace(),
+							},
+						},
+						"limit_max": schema.MapAttribute{
+							Description: "Maximum container resources allowed by the LimitRange.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								quantityValidator{},
+							},
+							PlanModifiers: []planmodifier.Map{
+								mapplanmodifier.RequiresReplace(),
+							},
+						},
+						"limit_min": schema.MapAttribute{
+							Description: "Minimum container resources required by the LimitRange.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								quantityValidator{},
+							},
+							PlanModifiers: []planmodifier.Map{
+								mapplanmodifier.RequiresReplace(),
+							},
+						},
+					},
+				},
+			},
+			"priority_classes": schema.ListNestedBlock{
+				Description: "PriorityClasses created after the nodes are ready. Existing objects with the same name are left in place.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the PriorityClass. Names starting with system- are reserved.",
+							Required:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+						"value": schema.Int64Attribute{
+							Description: "Priority value. User-defined classes must be at most 1000000000.",
+							Required:    true,
+							Validators: []validator.Int64{
+								int64BetweenValidator{min: math.MinInt32, max: highestUserDefinablePriority},
+							},
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.RequiresReplace(),
+							},
+						},
+						"global_default": schema.BoolAttribute{
+							Description: "Use this class for pods without a priorityClassName. At most one class may set it.",
+							Optional:    true,
+							PlanModifiers: []planmodifier.Bool{
+								boolplanmodifier.RequiresReplace(),
+							},
+						},
+						"preemption_policy": schema.StringAttribute{
+							Description: "Preemption policy: PreemptLowerPriority (default) or Never.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringOneOfValidator{values: preemptionPolicies},
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+					},
 				},
 			},
-			"wait_for_ready": schema.Int64Attribute{
-				Description: "Time in seconds to wait for the control plane to be ready. Default is 300 (5 minutes).",
-				Optional:    true,
-				Computed:    true,
-				Default:     int64default.StaticInt64(300),
-			},
-			"wait_for_nodes_ready": schema.BoolAttribute{
-				Description: "Wait for all nodes (including workers) to be in Ready state after cluster creation. Uses the wait_for_ready timeout. Default is true.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(true),
-			},
-			"feature_gates": schema.MapAttribute{
-				Description: "Kubernetes feature gates to enable/disable. Map of feature gate name to boolean.",
-				Optional:    true,
-				ElementType: types.BoolType,
-				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
+			"api_server_tracing": schema.SingleNestedBlock{
+				Description: "Export API server request traces over OTLP gRPC. The provider stages a TracingConfiguration on the control-plane nodes and points --tracing-config-file at it, enabling the APIServerTracing feature gate on node images older than Kubernetes 1.27, where it is off by default.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
 				},
-			},
-			"runtime_config": schema.MapAttribute{
-				Description: "Runtime configuration for kube-apiserver (--runtime-config flags). Used to enable alpha APIs.",
-				Optional:    true,
-				ElementType: types.StringType,
-				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
+				Attributes: map[string]schema.Attribute{
+					"endpoint": schema.StringAttribute{
+						Description: "host:port of the OpenTelemetry collector, dialed from the control-plane nodes, e.g. otel-collector:4317. The API server defaults to localhost:4317.",
+						Optional:    true,
+						Validators: []validator.String{
+							hostPortValidator{},
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"sampling_rate": schema.Float64Attribute{
+						Description: "Fraction of requests without a sampled parent span to trace, from 0 to 1. Defaults to 1, every request.",
+						Optional:    true,
+						PlanModifiers: []planmodifier.Float64{
+							float64planmodifier.RequiresReplace(),
+						},
+					},
 				},
 			},
-			"kubeadm_config_patches": schema.ListAttribute{
-				Description: "Kubeadm config patches (RFC 7386 merge patches) applied to all nodes.",
-				Optional:    true,
-				ElementType: types.StringType,
+			"event_rate_limit": schema.ListNestedBlock{
+				Description: "Limits for the EventRateLimit admission plugin, which is enabled on the API server together with the kubeadm default NodeRestriction when at least one block is set. Events beyond a limit are rejected with 429 Too Many Requests.",
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.RequiresReplace(),
 				},
-			},
-			"containerd_config_patches": schema.ListAttribute{
-				Description: "Containerd config patches (TOML format) applied to all nodes.",
-				Optional:    true,
-				ElementType: types.StringType,
-				PlanModifiers: []planmodifier.List{
-					listplanmodifier.RequiresReplace(),
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "What the limit applies to: Server (all events), Namespace, User or SourceAndObject (per event source and involved object).",
+							Required:    true,
+							Validators: []validator.String{
+								stringOneOfValidator{values: eventRateLimitTypes},
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+						"qps": schema.Int64Attribute{
+							Description: "Events per second accepted once the burst is used up.",
+							Required:    true,
+							Validators: []validator.Int64{
+								int64BetweenValidator{min: 1, max: math.MaxInt32},
+							},
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.RequiresReplace(),
+							},
+						},
+						"burst": schema.Int64Attribute{
+							Description: "Events accepted at once before qps applies.",
+							Required:    true,
+							Validators: []validator.Int64{
+								int64BetweenValidator{min: 1, max: math.MaxInt32},
+							},
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.RequiresReplace(),
+							},
+						},
+						"cache_size": schema.Int64Attribute{
+							Description: "Number of namespaces, users or sources tracked, least recently used first out. Not allowed for Server. Kubernetes defaults to 4096.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64BetweenValidator{min: 1, max: math.MaxInt32},
+							},
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.RequiresReplace(),
+							},
+						},
+					},
 				},
 			},
-			"containerd_config_patches_json6902": schema.ListAttribute{
-				Description: "Containerd config patches (RFC 6902 JSON patches) applied to all nodes.",
-				Optional:    true,
-				ElementType: types.StringType,
-				PlanModifiers: []planmodifier.List{
-					listplanmodifier.RequiresReplace(),
+			"drain_before_delete": schema.SingleNestedBlock{
+				Description: "Drain the cluster before deleting it: all nodes are cordoned and every pod not managed by a DaemonSet is evicted and given its termination grace period, so workloads run their shutdown. Pods that cannot be removed within drain_timeout are reported as warnings and the cluster is deleted anyway.",
+				Attributes: map[string]schema.Attribute{
+					"respect_pdbs": schema.BoolAttribute{
+						Description: "Evict through the Eviction API, honoring PodDisruptionBudgets until drain_timeout and deleting the pods they still protect after it. When false, pods are deleted right away. Default is true.",
+						Optional:    true,
+					},
+					"drain_timeout": schema.Int64Attribute{
+						Description: fmt.Sprintf("Time in seconds to wait for pods to be evicted and terminate. Default is %d.", defaultDrainTimeout),
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64AtLeastValidator{min: 1},
+						},
+					},
 				},
 			},
-			"kubeconfig": schema.StringAttribute{
-				Description: "The kubeconfig content for connecting to the cluster.",
-				Computed:    true,
-				Sensitive:   true,
-			},
-			"kubeconfig_path": schema.StringAttribute{
-				Description: "The path to the kubeconfig file.",
-				Computed:    true,
-			},
-			"client_certificate": schema.StringAttribute{
-				Description: "Base64 encoded client certificate for TLS authentication.",
-				Computed:    true,
-				Sensitive:   true,
-			},
-			"client_key": schema.StringAttribute{
-				Description: "Base64 encoded client key for TLS authentication.",
-				Computed:    true,
-				Sensitive:   true,
-			},
-			"cluster_ca_certificate": schema.StringAttribute{
-				Description: "Base64 encoded cluster CA certificate.",
-				Computed:    true,
-				Sensitive:   true,
-			},
-			"endpoint": schema.StringAttribute{
-				Description: "The Kubernetes API server endpoint.",
-				Computed:    true,
-			},
-		},
-		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 			"networking": schema.SingleNestedBlock{
 				Description: "Cluster networking configuration.",
 				Attributes: map[string]schema.Attribute{
@@ -298,8 +1221,11 @@ func (r *ClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						},
 					},
 					"kube_proxy_mode": schema.StringAttribute{
-						Description: "Kube-proxy mode: iptables, ipvs, or nftables.",
+						Description: "Kube-proxy mode: iptables, ipvs, nftables, or none to skip installing kube-proxy for a CNI that replaces it, such as Cilium, installed with disable_default_cni and cni_manifest. Empty uses the KinD default, iptables.",
 						Optional:    true,
+						Validators: []validator.String{
+							stringOneOfValidator{values: kubeProxyModes, allowEmpty: true},
+						},
 						PlanModifiers: []planmodifier.String{
 							stringplanmodifier.RequiresReplace(),
 						},
@@ -312,6 +1238,16 @@ func (r *ClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							listplanmodifier.RequiresReplace(),
 						},
 					},
+					"service_node_port_range": schema.StringAttribute{
+						Description: "Port range reserved for NodePort services (--service-node-port-range), e.g. 30000-32767.",
+						Optional:    true,
+						Validators: []validator.String{
+							portRangeValidator{},
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
 				},
 			},
 			"kubeadm_config_patches_json6902": schema.ListNestedBlock{
@@ -350,38 +1286,55 @@ func (r *ClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"node": schema.ListNestedBlock{
-				Description: "Node configuration. If not specified, creates 1 control-plane and 1 worker. Changes trigger cluster recreation.",
+				Description: "Node configuration. If not specified, creates 1 control-plane and 1 worker. Adding or removing workers at the end of the list is applied in place; other changes trigger cluster recreation.",
+				PlanModifiers: []planmodifier.List{
+					workerScalingPlanModifier{},
+				},
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"role": schema.StringAttribute{
 							Description: "Node role: control-plane or worker.",
 							Required:    true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.RequiresReplace(),
+							Validators: []validator.String{
+								stringOneOfValidator{values: nodeRoles},
 							},
 						},
 						"image": schema.StringAttribute{
 							Description: "Node image. Overrides cluster-level node_image.",
 							Optional:    true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.RequiresReplace(),
-							},
 						},
 						"labels": schema.MapAttribute{
-							Description: "Kubernetes labels for the node.",
+							Description: "Kubernetes labels for the node, set by the kubelet when it registers. Changes recreate the cluster.",
 							Optional:    true,
 							ElementType: types.StringType,
-							PlanModifiers: []planmodifier.Map{
-								mapplanmodifier.RequiresReplace(),
+						},
+						"extra_labels": schema.MapAttribute{
+							Description: "Kubernetes labels patched onto the node object once it is ready, including labels the kubelet may not set on itself such as node-role.kubernetes.io/*. Changes are applied in place; removed keys are deleted from the node.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"extra_args": schema.ListAttribute{
+							Description: "Advanced: extra flags applied to the node container with the container runtime's update command after creation, e.g. --cpus=2 or --memory=4g. Not supported by KinD itself; misuse can break the node.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"ulimits": schema.MapAttribute{
+							Description: "Ulimits for the node as soft:hard pairs, e.g. nofile = \"65536:65536\". Supported: nofile, nproc. Container runtimes cannot change them on a running container, so they are applied inside the node after creation to its init process and the containerd and kubelet services. Changes recreate the cluster.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								ulimitsValidator{},
 							},
 						},
+						"kubelet_extra_args": schema.MapAttribute{
+							Description: "Kubelet flags for this node without the leading --, e.g. max-pods = \"200\" or system-reserved = \"cpu=500m,memory=512Mi\". Rendered into the nodeRegistration.kubeletExtraArgs of the node's kubeadm InitConfiguration and JoinConfiguration, ahead of kubeadm_config_patches. node-ip, provider-id and node-labels are set by KinD. Changes recreate the cluster.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
 						"kubeadm_config_patches": schema.ListAttribute{
 							Description: "Kubeadm config patches for this node (RFC 7386 merge patches).",
 							Optional:    true,
 							ElementType: types.StringType,
-							PlanModifiers: []planmodifier.List{
-								listplanmodifier.RequiresReplace(),
-							},
 						},
 					},
 					Blocks: map[string]schema.Block{
@@ -392,37 +1345,22 @@ func (r *ClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 									"host_path": schema.StringAttribute{
 										Description: "Path on the host.",
 										Required:    true,
-										PlanModifiers: []planmodifier.String{
-											stringplanmodifier.RequiresReplace(),
-										},
 									},
 									"container_path": schema.StringAttribute{
 										Description: "Path in the container.",
 										Required:    true,
-										PlanModifiers: []planmodifier.String{
-											stringplanmodifier.RequiresReplace(),
-										},
 									},
 									"read_only": schema.BoolAttribute{
 										Description: "Read-only mount.",
 										Optional:    true,
-										PlanModifiers: []planmodifier.Bool{
-											boolplanmodifier.RequiresReplace(),
-										},
 									},
 									"selinux_relabel": schema.BoolAttribute{
 										Description: "Enable SELinux relabeling.",
 										Optional:    true,
-										PlanModifiers: []planmodifier.Bool{
-											boolplanmodifier.RequiresReplace(),
-										},
 									},
 									"propagation": schema.StringAttribute{
 										Description: "Mount propagation: None, HostToContainer, or Bidirectional.",
 										Optional:    true,
-										PlanModifiers: []planmodifier.String{
-											stringplanmodifier.RequiresReplace(),
-										},
 									},
 								},
 							},
@@ -434,30 +1372,18 @@ func (r *ClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 									"container_port": schema.Int64Attribute{
 										Description: "Port in the container.",
 										Required:    true,
-										PlanModifiers: []planmodifier.Int64{
-											int64planmodifier.RequiresReplace(),
-										},
 									},
 									"host_port": schema.Int64Attribute{
 										Description: "Port on the host.",
 										Required:    true,
-										PlanModifiers: []planmodifier.Int64{
-											int64planmodifier.RequiresReplace(),
-										},
 									},
 									"listen_address": schema.StringAttribute{
 										Description: "Host bind address. Defaults to 127.0.0.1.",
 										Optional:    true,
-										PlanModifiers: []planmodifier.String{
-											stringplanmodifier.RequiresReplace(),
-										},
 									},
 									"protocol": schema.StringAttribute{
 										Description: "Protocol: TCP, UDP, or SCTP.",
 										Optional:    true,
-										PlanModifiers: []planmodifier.String{
-											stringplanmodifier.RequiresReplace(),
-										},
 									},
 								},
 							},
@@ -469,30 +1395,18 @@ func (r *ClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 									"group": schema.StringAttribute{
 										Description: "API group.",
 										Required:    true,
-										PlanModifiers: []planmodifier.String{
-											stringplanmodifier.RequiresReplace(),
-										},
 									},
 									"version": schema.StringAttribute{
 										Description: "API version.",
 										Required:    true,
-										PlanModifiers: []planmodifier.String{
-											stringplanmodifier.RequiresReplace(),
-										},
 									},
 									"kind": schema.StringAttribute{
 										Description: "Resource kind.",
 										Required:    true,
-										PlanModifiers: []planmodifier.String{
-											stringplanmodifier.RequiresReplace(),
-										},
 									},
 									"patch": schema.StringAttribute{
 										Description: "JSON patch content.",
 										Required:    true,
-										PlanModifiers: []planmodifier.String{
-											stringplanmodifier.RequiresReplace(),
-										},
 									},
 								},
 							},
@@ -504,68 +1418,363 @@ func (r *ClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 	}
 }
 
-func (r *ClusterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
+func (r *ClusterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KindProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *KindProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.provider = providerData.Provider
+	r.runtime = providerData.Runtime
+	r.metricsFile = providerData.MetricsFile
+	r.diagnosticsFile = providerData.DiagnosticsFile
+	r.defaultNodeImage = providerData.DefaultNodeImage
+}
+
+func (r *ClusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ClusterResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateConfigPatches(&data, &resp.Diagnostics)
+	validateRuntimeClass(&data, &resp.Diagnostics)
+	validateCRIDeviceOwnership(&data, &resp.Diagnostics)
+	validatePriorityClasses(&data, &resp.Diagnostics)
+	validateEventRateLimits(&data, &resp.Diagnostics)
+	validateAPIServerTracing(&data, &resp.Diagnostics)
+	validateCNIManifest(&data, &resp.Diagnostics)
+	validateNodeLocalDNS(&data, &resp.Diagnostics)
+	validateNamespacePolicies(&data, &resp.Diagnostics)
+	validateImageCachePassthrough(&data, &resp.Diagnostics)
+	validateNodeExtraArgs(data.Nodes, &resp.Diagnostics)
+	validateKubeletExtraArgs(data.Nodes, &resp.Diagnostics)
+	validateNodeExtraLabels(data.Nodes, &resp.Diagnostics)
+	validateNodePortRangeOverlap(&data, &resp.Diagnostics)
+	validateKubeletLogRotation(&data, &resp.Diagnostics)
+	validateGracefulNodeShutdown(&data, &resp.Diagnostics)
+	validateGoawayChance(&data, &resp.Diagnostics)
+	validateAPIServerExtraArgs(&data, &resp.Diagnostics)
+	validateNodeImageDigest(&data, &resp.Diagnostics)
+	validateEnableAPIs(&data, &resp.Diagnostics)
+	validateRBACManifests(&data, &resp.Diagnostics)
+	validateRegistryMirrors(&data, &resp.Diagnostics)
+	validateLocalRegistry(&data, &resp.Diagnostics)
+	validateRestartWorkloads(&data, &resp.Diagnostics)
+	validateKubeconfigContextName(&data, &resp.Diagnostics)
+	validateKubeProxyMode(&data, &resp.Diagnostics)
+	validateStopBeforeKubernetes(&data, &resp.Diagnostics)
+	validateImageArchives(&data, &resp.Diagnostics)
+}
+
+func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Clean up any stale lock files from previous interrupted operations
+	cleanupStaleLockFile(ctx)
+
+	var data ClusterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// KinD's own create cannot be cancelled and is bounded by wait_for_ready;
+	// the deadline applies to the readiness waits and post-create steps.
+	ctx, cancel, _ := operationContext(ctx, data.Timeouts, "create", &resp.Diagnostics)
+	defer cancel()
+
+	clusterName := data.Name.ValueString()
+
+	// The provider's default_node_image stands in for an unset node_image,
+	// which then records it.
+	if (data.NodeImage.IsNull() || data.NodeImage.IsUnknown()) && r.defaultNodeImage != "" {
+		data.NodeImage = types.StringValue(r.defaultNodeImage)
+	}
+
+	cfg := r.buildClusterConfig(&data)
+
+	start := time.Now()
+	defer func() {
+		r.recordMetrics(clusterName, "create", start, len(cfg.Nodes), &resp.Diagnostics)
+		r.recordOperation(clusterName, "create", start, &resp.Diagnostics)
+	}()
+
+	skipPreflight := data.SkipPreflight.ValueBool()
+
+	if data.CheckHostLimits.ValueBool() && !skipPreflight {
+		if problems := checkHostLimits(len(cfg.Nodes)); len(problems) > 0 {
+			resp.Diagnostics.AddError(
+				"Host limits too low for cluster",
+				"The host kernel limits are below what KinD needs for this cluster and nodes are likely to fail "+
+					"with \"too many open files\" errors. Raise the following limits, or set check_host_limits = false "+
+					"to skip this check:\n\n"+strings.Join(problems, "\n"),
+			)
+			return
+		}
+	}
+
+	if !skipPreflight {
+		if problems := checkHostRecommendations(); len(problems) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Host limits below KinD recommendations",
+				"Pods may crash-loop with \"too many open files\" or inotify errors once the cluster runs workloads. "+
+					"Raise the following limits, and persist them in /etc/sysctl.d, or set skip_preflight = true to skip the host checks:\n\n"+
+					strings.Join(problems, "\n"),
+			)
+		}
+	}
+
+	// KinD's default subnets are checked as well, they can overlap too.
+	var overlaps []string
+	if !skipPreflight {
+		defaulted := &v1alpha4.Cluster{Networking: cfg.Networking}
+		v1alpha4.SetDefaultsCluster(defaulted)
+		overlaps = checkSubnetOverlap(map[string]string{
+			"pod_subnet":     defaulted.Networking.PodSubnet,
+			"service_subnet": defaulted.Networking.ServiceSubnet,
+		}, listHostNetworks(ctx, r.runtime))
+	}
+	if len(overlaps) > 0 {
+		summary := "Cluster subnets overlap host networks"
+		detail := "Traffic to the overlapping addresses may be routed to the wrong network, which breaks pod or service connectivity in ways that are hard to trace. " +
+			"Choose a different networking.pod_subnet or networking.service_subnet:\n\n" + strings.Join(overlaps, "\n")
+		if data.RequireNoSubnetOverlap.ValueBool() {
+			resp.Diagnostics.AddError(summary, detail)
+			return
+		}
+		resp.Diagnostics.AddWarning(summary, detail+"\n\nSet require_no_subnet_overlap = true to fail instead.")
+	}
+
+	if !data.NodeImageDigest.IsNull() {
+		if err := verifyNodeImageDigest(ctx, r.runtime, resolvedNodeImage(&data), data.NodeImageDigest.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("node_image_digest"), "Node image digest mismatch", err.Error())
+			return
+		}
+	}
+
+	if err := stageClusterFiles(clusterName, cfg, clusterFiles(&data)); err != nil {
+		resp.Diagnostics.AddError("Failed to write cluster files", err.Error())
+		return
+	}
+
+	if data.EnableImageCachePassthrough.ValueBool() {
+		if err := stageImageCache(cfg); err != nil {
+			resp.Diagnostics.AddError("Failed to prepare image cache", err.Error())
+			return
+		}
+	}
+
+	// KinD's own wait blocks on control-plane node readiness, which never comes
+	// before a CNI is installed, so it is skipped when only the API is awaited.
+	kindWait := time.Duration(data.WaitForReady.ValueInt64()) * time.Second
+	if data.WaitForAPIOnly.ValueBool() {
+		kindWait = 0
+	}
+
+	createOpts := []cluster.CreateOption{
+		cluster.CreateWithV1Alpha4Config(cfg),
+		cluster.CreateWithWaitForReady(kindWait),
+		cluster.CreateWithDisplayUsage(false),
+		cluster.CreateWithDisplaySalutation(false),
+	}
+
+	if !data.NodeImage.IsNull() && data.NodeImage.ValueString() != "" {
+		createOpts = append(createOpts, cluster.CreateWithNodeImage(data.NodeImage.ValueString()))
+	}
+
+	stopBeforeKubernetes := data.StopBeforeKubernetes.ValueBool()
+	if stopBeforeKubernetes {
+		createOpts = append(createOpts, cluster.CreateWithStopBeforeSettingUpKubernetes(true))
+	}
+
+	// KinD writes the kubeconfig to the default file unless given a path, so
+	// it goes to the per-cluster file and is merged only when asked to.
+	kubeconfigPath, err := kindKubeconfigPath(clusterName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get home directory", err.Error())
 		return
 	}
+	createOpts = append(createOpts, cluster.CreateWithKubeconfigPath(kubeconfigPath))
+
+	// KinD deletes a cluster that failed to come up unless it is retained, so
+	// it is retained to collect its logs and deleted here afterwards, unless
+	// retain_on_failure keeps it for inspection.
+	exportLogs := data.ExportLogsOnFailure.ValueBool()
+	retain := data.RetainOnFailure.ValueBool()
+	if exportLogs || retain {
+		createOpts = append(createOpts, cluster.CreateWithRetain(true))
+	}
 
-	provider, ok := req.ProviderData.(*cluster.Provider)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *cluster.Provider, got: %T", req.ProviderData),
+	attempts, err := r.createWithRetries(ctx, clusterName, kubeconfigPath, int(data.CreateRetries.ValueInt64()), createOpts)
+
+	// A cluster left behind by an apply interrupted before the state was
+	// written is adopted when asked to; it is never deleted here.
+	adopted := false
+	if isClusterExistsError(err) {
+		if !data.AdoptExisting.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Cluster already exists",
+				fmt.Sprintf("A KinD cluster named %s already exists but is not tracked in the Terraform state, usually because an earlier apply was interrupted after creating it. "+
+					"Import it with `terraform import <resource address> %s`, set adopt_existing = true to take it over on the next apply, or delete it with `kind delete cluster --name %s`.",
+					clusterName, clusterName, clusterName),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddWarning(
+			"Adopted existing cluster",
+			fmt.Sprintf("The KinD cluster %s already existed and was adopted instead of created (adopt_existing). Node settings applied right after creation, such as extra args, registry mirrors and loaded images, were not applied again.", clusterName),
 		)
+		adopted, err = true, nil
+	}
+
+	if err != nil {
+		detail := fmt.Sprintf("Cluster creation failed after %d attempt(s): %s", attempts, err)
+		if exportLogs {
+			detail += "\n\n" + r.exportClusterLogs(clusterName, data.LogExportPath.ValueString())
+		}
+		if retain {
+			detail += fmt.Sprintf("\n\nThe nodes of the failed cluster were retained for inspection (retain_on_failure) and are not tracked in the Terraform state. "+
+				"Inspect them with `%s ps --filter label=%s=%s` and delete them with `kind delete cluster --name %s` before applying again.",
+				r.runtime, kindClusterLabelKey, clusterName, clusterName)
+		} else if exportLogs {
+			if err := r.provider.Delete(clusterName, kubeconfigPath); err != nil {
+				detail += fmt.Sprintf("\n\nDeleting the failed cluster failed: %s", err)
+			}
+		}
+		resp.Diagnostics.AddError("Failed to create cluster", detail)
 		return
 	}
 
-	r.provider = provider
-}
+	// Later steps fail with the cluster still running; its logs are exported
+	// alongside whatever error they report.
+	defer func() {
+		if exportLogs && resp.Diagnostics.HasError() {
+			resp.Diagnostics.AddWarning("Cluster creation failed", r.exportClusterLogs(clusterName, data.LogExportPath.ValueString()))
+		}
+	}()
 
-func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	// Clean up any stale lock files from previous interrupted operations
-	cleanupStaleLockFile()
+	if data.MergeKubeconfig.ValueBool() && !stopBeforeKubernetes {
+		if err := r.mergeKubeconfig(ctx, clusterName, kubeconfigContextName(&data)); err != nil {
+			resp.Diagnostics.AddError("Failed to merge kubeconfig", kubeconfigErrorDetail(err))
+			return
+		}
+	}
 
-	var data ClusterResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// The registry joins the KinD network, which exists once the cluster
+	// does, and is reused when it already runs.
+	if data.LocalRegistry != nil {
+		created, err := startLocalRegistry(ctx, r.runtime, data.LocalRegistry)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to start local registry", err.Error())
+			return
+		}
+		if created {
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, localRegistryPrivateKey, []byte(`true`))...)
+		}
+	}
+
+	// An adopted cluster went through these steps, or was interrupted
+	// during them, in the apply that created it.
+	if !adopted {
+		r.configureNodes(ctx, &data, cfg, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	r.populateComputedValues(ctx, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	clusterName := data.Name.ValueString()
+	// The nodes run but Kubernetes is left for the user to bootstrap, so
+	// nothing needing the API server can follow.
+	if stopBeforeKubernetes {
+		data.AppliedManifests = types.ListNull(types.ObjectType{AttrTypes: appliedManifestAttrTypes})
+		r.populateClusterStatus(ctx, &data, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 
-	cfg := r.buildClusterConfig(&data)
+	// Nodes stay NotReady until a CNI runs, so it goes in before the wait.
+	if !data.CNIManifest.IsNull() {
+		r.installCNI(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	createOpts := []cluster.CreateOption{
-		cluster.CreateWithV1Alpha4Config(cfg),
-		cluster.CreateWithWaitForReady(time.Duration(data.WaitForReady.ValueInt64()) * time.Second),
-		cluster.CreateWithDisplayUsage(false),
-		cluster.CreateWithDisplaySalutation(false),
+	// Logs, exec and metrics scraping need the kubelet serving certificates,
+	// so they are approved before waiting on workloads.
+	if data.AutoApproveKubeletCerts.ValueBool() {
+		r.approveKubeletCerts(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	if !data.NodeImage.IsNull() && data.NodeImage.ValueString() != "" {
-		createOpts = append(createOpts, cluster.CreateWithNodeImage(data.NodeImage.ValueString()))
+	waitForCluster(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	err := r.provider.Create(clusterName, createOpts...)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to create cluster", err.Error())
+	applyNodeExtraLabels(ctx, &data, nil, nodeContainerNames(clusterName, cfg.Nodes), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	r.populateComputedValues(&data, &resp.Diagnostics)
+	r.bootstrapCluster(ctx, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Wait for all nodes to be ready if enabled
-	if !data.WaitForNodesReady.IsNull() && data.WaitForNodesReady.ValueBool() {
-		timeout := time.Duration(data.WaitForReady.ValueInt64()) * time.Second
-		if err := waitForAllNodesReady(ctx, data.Kubeconfig.ValueString(), timeout); err != nil {
-			resp.Diagnostics.AddError("Failed waiting for nodes to be ready", err.Error())
+	if data.CoreDNS != nil {
+		configureCoreDNS(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if data.InstallNodeLocalDNS.ValueBool() {
+		r.installNodeLocalDNS(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if data.InstallCertManager.ValueBool() {
+		r.installCertManager(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.AppliedManifests = types.ListNull(types.ObjectType{AttrTypes: appliedManifestAttrTypes})
+	if !data.ApplyManifests.IsNull() {
+		applyManifests(ctx, &data, nil, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
 
+	// The bundle describes a cluster that came up, so it is only written once
+	// the readiness waits and setup steps have passed.
+	exportClusterBundle(&data, cfg, "", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.populateClusterStatus(ctx, &data, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -576,8 +1785,16 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	ctx, cancel, _ := operationContext(ctx, data.Timeouts, "read", &resp.Diagnostics)
+	defer cancel()
+
 	clusterName := data.Name.ValueString()
 
+	// Refreshes run on every plan, so they are only recorded in the
+	// diagnostics file and never emit metrics samples.
+	start := time.Now()
+	defer r.recordOperation(clusterName, "read", start, &resp.Diagnostics)
+
 	clusters, err := r.provider.List()
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to list clusters", err.Error())
@@ -597,33 +1814,194 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	r.populateComputedValues(&data, &resp.Diagnostics)
+	// node_image used to default to an empty string; it is resolved like an
+	// unset one.
+	if data.NodeImage.ValueString() == "" {
+		data.NodeImage = types.StringNull()
+	}
+
+	imported, d := req.Private.GetKey(ctx, importedPrivateKey)
+	resp.Diagnostics.Append(d...)
+	if imported != nil {
+		r.reconstructClusterConfig(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	}
+
+	r.populateComputedValues(ctx, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	r.populateClusterStatus(ctx, &data, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ClusterResourceModel
+	var data, state ClusterResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, _ := operationContext(ctx, data.Timeouts, "update", &resp.Diagnostics)
+	defer cancel()
+
+	start := time.Now()
+	defer func() {
+		r.recordMetrics(data.Name.ValueString(), "update", start, len(r.buildClusterConfig(&data).Nodes), &resp.Diagnostics)
+		r.recordOperation(data.Name.ValueString(), "update", start, &resp.Diagnostics)
+	}()
+
+	added := addedImages(listStringValues(state.LoadedImages), listStringValues(data.LoadedImages))
+	addedArchives := addedImages(listStringValues(state.ImageArchives), listStringValues(data.ImageArchives))
+	if len(added) > 0 || len(addedArchives) > 0 {
+		withImageCacheLock(ctx, &data, &resp.Diagnostics, func() {
+			r.loadImages(ctx, data.Name.ValueString(), added, &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			r.loadArchives(ctx, data.Name.ValueString(), addedArchives, &resp.Diagnostics)
+		})
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if len(added) > 0 || len(addedArchives) > 0 {
+		restartWorkloads(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Workers added here get every loaded image, so this runs after the
+	// existing nodes received the newly added ones.
+	r.scaleWorkers(ctx, &state, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.AutoApproveKubeletCerts.ValueBool() && len(data.Nodes) > len(state.Nodes) {
+		r.approveKubeletCerts(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	applyNodeExtraLabels(ctx, &data, state.Nodes, nodeContainerNames(data.Name.ValueString(), r.buildClusterConfig(&data).Nodes), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// The kubeconfig is written to a new kubeconfig_output_path below, and
+	// the file the provider wrote before is removed.
+	if oldPath := state.KubeconfigOutputPath.ValueString(); oldPath != "" && oldPath != data.KubeconfigOutputPath.ValueString() {
+		if oldPath, err := expandPath(oldPath); err == nil {
+			if err := removeOwnedKubeconfigFile(oldPath, kubeconfigContextName(&state)); err != nil {
+				resp.Diagnostics.AddWarning("Failed to remove kubeconfig", err.Error())
+			}
+		}
+	}
+
+	// A renamed context is merged again under its new name.
+	merge, merged := data.MergeKubeconfig.ValueBool(), state.MergeKubeconfig.ValueBool()
+	contextName, mergedName := kubeconfigContextName(&data), kubeconfigContextName(&state)
+	if merge != merged || (merge && contextName != mergedName) {
+		var err error
+		if merged {
+			err = removeKubeconfigEntries(ctx, defaultKubeconfigPath(), mergedName)
+		}
+		if err == nil && merge {
+			err = r.mergeKubeconfig(ctx, data.Name.ValueString(), contextName)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update default kubeconfig", kubeconfigErrorDetail(err))
+			return
+		}
+	}
+
+	// The plan modifier only lets appended control-plane patches through.
+	if planned, current := listStringValues(data.KubeadmConfigPatches), listStringValues(state.KubeadmConfigPatches); len(planned) > len(current) {
+		r.applyKubeadmPatches(ctx, &data, planned[len(current):], &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if nodeTimezone(data.NodeTimezone) != nodeTimezone(state.NodeTimezone) {
+		applyNodeTimezone(ctx, r.provider, data.Name.ValueString(), nodeTimezone(data.NodeTimezone), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Populate computed values from the existing cluster
-	r.populateComputedValues(&data, &resp.Diagnostics)
+	r.populateComputedValues(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.CNIManifest.IsNull() && !data.CNIManifest.Equal(state.CNIManifest) {
+		r.installCNI(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if coreDNSChanged(data.CoreDNS, state.CoreDNS) {
+		configureCoreDNS(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if data.InstallCertManager.ValueBool() && (!state.InstallCertManager.ValueBool() || certManagerVersion(&data) != certManagerVersion(&state)) {
+		r.installCertManager(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.AppliedManifests = state.AppliedManifests
+	if !data.ApplyManifests.Equal(state.ApplyManifests) {
+		var previous []AppliedManifestModel
+		resp.Diagnostics.Append(state.AppliedManifests.ElementsAs(ctx, &previous, false)...)
+		applyManifests(ctx, &data, previous, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The waits run last so they cover every in-place change above.
+	if data.RevalidateAfterUpdate.ValueBool() {
+		waitForCluster(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Like on create, the bundle is written after the waits. It is rewritten
+	// on every update so it follows scaled workers and loaded images, and a
+	// moved export_bundle_path removes the bundle at the old path.
+	exportClusterBundle(&data, r.buildClusterConfig(&data), state.ExportBundlePath.ValueString(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	r.populateClusterStatus(ctx, &data, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Clean up any stale lock files from previous interrupted operations
-	cleanupStaleLockFile()
+	cleanupStaleLockFile(ctx)
 
 	var data ClusterResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -633,15 +2011,129 @@ func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	clusterName := data.Name.ValueString()
 
-	err := r.provider.Delete(clusterName, "")
+	start := time.Now()
+	defer func() {
+		r.recordMetrics(clusterName, "delete", start, len(r.buildClusterConfig(&data).Nodes), &resp.Diagnostics)
+		r.recordOperation(clusterName, "delete", start, &resp.Diagnostics)
+	}()
+
+	ctx, cancel, timeout := operationContext(ctx, data.Timeouts, "delete", &resp.Diagnostics)
+	defer cancel()
+
+	kubeconfigPath, err := kindKubeconfigPath(clusterName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get home directory", err.Error())
+		return
+	}
+
+	if data.DeleteManifestsOnDestroy.ValueBool() {
+		deleteManifests(ctx, &data, &resp.Diagnostics)
+	}
+
+	// Draining is best-effort: the cluster is deleted regardless.
+	if drain := data.DrainBeforeDelete; drain != nil {
+		drainTimeout := time.Duration(defaultDrainTimeout) * time.Second
+		if !drain.DrainTimeout.IsNull() {
+			drainTimeout = time.Duration(drain.DrainTimeout.ValueInt64()) * time.Second
+		}
+		respectPDBs := drain.RespectPDBs.IsNull() || drain.RespectPDBs.ValueBool()
+
+		clientset, err := newKubernetesClientset(clientKubeconfig(&data))
+		var stuck []string
+		if err == nil {
+			stuck, err = drainCluster(ctx, clientset, respectPDBs, drainTimeout)
+		}
+		if err != nil {
+			resp.Diagnostics.AddWarning("Failed to drain cluster", err.Error())
+		}
+		if len(stuck) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Pods not evicted before delete",
+				fmt.Sprintf("These pods did not finish within the drain timeout of %s:\n  %s", drainTimeout, strings.Join(stuck, "\n  ")),
+			)
+		}
+	}
+
+	// KinD's delete does not take a context, so it runs in the background and
+	// the deadline only bounds how long Terraform waits for it.
+	deleted := make(chan error, 1)
+	go func() {
+		deleted <- r.provider.Delete(clusterName, kubeconfigPath)
+	}()
+
+	select {
+	case err = <-deleted:
+	case <-ctx.Done():
+		// A node that stopped responding can hang KinD's delete forever, so
+		// the containers are removed directly rather than wedging destroy.
+		removed, forceErr := forceRemoveClusterContainers(ctx, r.runtime, clusterName)
+		if forceErr != nil {
+			err = fmt.Errorf("timed out after %s waiting for the cluster to be deleted, and removing its containers failed: %w", timeout, forceErr)
+			break
+		}
+		resp.Diagnostics.AddWarning(
+			"Cluster force-deleted",
+			fmt.Sprintf("KinD did not delete the cluster within %s, so its node containers were removed with `%s rm -f`: %s. "+
+				"Check for leftover networks or volumes if the runtime was unhealthy.", timeout, r.runtime, strings.Join(removed, ", ")),
+		)
+	}
+	sharedClientsets.evict(clientKubeconfig(&data))
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete cluster", err.Error())
+		resp.Diagnostics.AddError("Failed to delete cluster", kubeconfigErrorDetail(err))
 		return
 	}
+
+	if err := removeClusterFiles(clusterName); err != nil {
+		resp.Diagnostics.AddWarning("Failed to remove cluster files", err.Error())
+	}
+
+	// A registry that already ran before the cluster was created may serve
+	// other clusters, so only one created with it is removed.
+	registryCreated, d := req.Private.GetKey(ctx, localRegistryPrivateKey)
+	resp.Diagnostics.Append(d...)
+	if data.LocalRegistry != nil && registryCreated != nil {
+		if err := removeLocalRegistry(ctx, r.runtime, data.LocalRegistry); err != nil {
+			resp.Diagnostics.AddWarning("Failed to remove local registry", err.Error())
+		}
+	}
+
+	if err := os.Remove(kubeconfigPath); err != nil && !os.IsNotExist(err) {
+		resp.Diagnostics.AddWarning("Failed to remove kubeconfig", err.Error())
+	}
+
+	if outputPath := data.KubeconfigOutputPath.ValueString(); outputPath != "" {
+		if outputPath, err = expandPath(outputPath); err == nil {
+			err = removeOwnedKubeconfigFile(outputPath, kubeconfigContextName(&data))
+		}
+		if err != nil {
+			resp.Diagnostics.AddWarning("Failed to remove kubeconfig", err.Error())
+		}
+	}
+
+	// State written before merge_kubeconfig existed has it null; those
+	// clusters were merged, as KinD always does.
+	if data.MergeKubeconfig.IsNull() || data.MergeKubeconfig.ValueBool() {
+		if err := removeKubeconfigEntries(ctx, defaultKubeconfigPath(), kubeconfigContextName(&data)); err != nil {
+			resp.Diagnostics.AddWarning("Failed to remove cluster from default kubeconfig", kubeconfigErrorDetail(err))
+		}
+	}
+
+	if bundlePath := data.ExportBundlePath.ValueString(); bundlePath != "" {
+		if err := removeExportBundle(bundlePath); err != nil {
+			resp.Diagnostics.AddWarning("Failed to remove export bundle", err.Error())
+		}
+	}
 }
 
 func (r *ClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read reconstructs the configuration once, right after the import.
+	r.setImportDefaults(ctx, &resp.State, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, []byte(`true`))...)
 }
 
 func (r *ClusterResource) buildClusterConfig(data *ClusterResourceModel) *v1alpha4.Cluster {
@@ -669,9 +2161,25 @@ func (r *ClusterResource) buildClusterConfig(data *ClusterResourceModel) *v1alph
 		cfg.FeatureGates = featureGates
 	}
 
-	// Runtime config
+	// Older node images need the tracing gate turned on; an explicit false
+	// is rejected by validateAPIServerTracing.
+	if tracingNeedsFeatureGate(data) {
+		if cfg.FeatureGates == nil {
+			cfg.FeatureGates = map[string]bool{}
+		}
+		cfg.FeatureGates[apiServerTracingFeatureGate] = true
+	}
+
+	// Runtime config, with explicit runtime_config entries overriding
+	// enable_apis.
+	if !data.EnableAPIs.IsNull() && len(data.EnableAPIs.Elements()) > 0 {
+		cfg.RuntimeConfig = runtimeConfigFromEnableAPIs(listStringValues(data.EnableAPIs))
+	}
 	if !data.RuntimeConfig.IsNull() && len(data.RuntimeConfig.Elements()) > 0 {
-		runtimeConfig := make(map[string]string)
+		runtimeConfig := cfg.RuntimeConfig
+		if runtimeConfig == nil {
+			runtimeConfig = make(map[string]string)
+		}
 		for k, v := range data.RuntimeConfig.Elements() {
 			if strVal, ok := v.(types.String); ok && !strVal.IsNull() {
 				runtimeConfig[k] = strVal.ValueString()
@@ -680,15 +2188,25 @@ func (r *ClusterResource) buildClusterConfig(data *ClusterResourceModel) *v1alph
 		cfg.RuntimeConfig = runtimeConfig
 	}
 
+	// Kubeadm config patches generated from typed attributes. These are
+	// applied before user patches so raw patches can still override them.
+	if patch := buildClusterConfigurationPatch(data); patch != "" {
+		cfg.KubeadmConfigPatches = append(cfg.KubeadmConfigPatches, patch)
+	}
+	if patch := buildKubeletConfigPatch(data); patch != "" {
+		cfg.KubeadmConfigPatches = append(cfg.KubeadmConfigPatches, patch)
+	}
+	if patch := buildKubeProxyConfigPatch(data); patch != "" {
+		cfg.KubeadmConfigPatches = append(cfg.KubeadmConfigPatches, patch)
+	}
+
 	// Kubeadm config patches (merge patches)
 	if !data.KubeadmConfigPatches.IsNull() && len(data.KubeadmConfigPatches.Elements()) > 0 {
-		patches := make([]string, 0, len(data.KubeadmConfigPatches.Elements()))
 		for _, elem := range data.KubeadmConfigPatches.Elements() {
 			if strVal, ok := elem.(types.String); ok && !strVal.IsNull() {
-				patches = append(patches, strVal.ValueString())
+				cfg.KubeadmConfigPatches = append(cfg.KubeadmConfigPatches, strVal.ValueString())
 			}
 		}
-		cfg.KubeadmConfigPatches = patches
 	}
 
 	// Kubeadm config patches (JSON6902)
@@ -705,15 +2223,17 @@ func (r *ClusterResource) buildClusterConfig(data *ClusterResourceModel) *v1alph
 		cfg.KubeadmConfigPatchesJSON6902 = patches
 	}
 
+	// Containerd config patches generated from typed attributes, applied
+	// before user patches so raw patches can still override them.
+	cfg.ContainerdConfigPatches = buildContainerdConfigPatches(data)
+
 	// Containerd config patches (TOML)
 	if !data.ContainerdConfigPatches.IsNull() && len(data.ContainerdConfigPatches.Elements()) > 0 {
-		patches := make([]string, 0, len(data.ContainerdConfigPatches.Elements()))
 		for _, elem := range data.ContainerdConfigPatches.Elements() {
 			if strVal, ok := elem.(types.String); ok && !strVal.IsNull() {
-				patches = append(patches, strVal.ValueString())
+				cfg.ContainerdConfigPatches = append(cfg.ContainerdConfigPatches, strVal.ValueString())
 			}
 		}
-		cfg.ContainerdConfigPatches = patches
 	}
 
 	// Containerd config patches (JSON6902)
@@ -734,15 +2254,21 @@ func (r *ClusterResource) buildClusterConfig(data *ClusterResourceModel) *v1alph
 			cfg.Nodes[i] = r.buildNodeConfig(&node)
 		}
 	} else {
-		cfg.Nodes = []v1alpha4.Node{
-			{Role: v1alpha4.ControlPlaneRole},
-			{Role: v1alpha4.WorkerRole},
-		}
+		cfg.Nodes = defaultNodes()
 	}
 
 	return cfg
 }
 
+// defaultNodes returns the nodes KinD creates when no node is configured: one
+// control plane and one worker.
+func defaultNodes() []v1alpha4.Node {
+	return []v1alpha4.Node{
+		{Role: v1alpha4.ControlPlaneRole},
+		{Role: v1alpha4.WorkerRole},
+	}
+}
+
 func (r *ClusterResource) buildNetworkingConfig(net *NetworkingModel) v1alpha4.Networking {
 	networking := v1alpha4.Networking{}
 
@@ -813,9 +2339,11 @@ func (r *ClusterResource) buildNodeConfig(node *NodeModel) v1alpha4.Node {
 		n.Labels = labels
 	}
 
-	// Kubeadm config patches (merge patches) for this node
+	// Kubeadm config patches (merge patches) for this node, after the ones
+	// generated from kubelet_extra_args so raw patches can override them.
+	n.KubeadmConfigPatches = buildKubeletExtraArgsPatches(node)
 	if !node.KubeadmConfigPatches.IsNull() && len(node.KubeadmConfigPatches.Elements()) > 0 {
-		patches := make([]string, 0, len(node.KubeadmConfigPatches.Elements()))
+		patches := n.KubeadmConfigPatches
 		for _, elem := range node.KubeadmConfigPatches.Elements() {
 			if strVal, ok := elem.(types.String); ok && !strVal.IsNull() {
 				patches = append(patches, strVal.ValueString())
@@ -874,68 +2402,204 @@ func (r *ClusterResource) buildNodeConfig(node *NodeModel) v1alpha4.Node {
 	return n
 }
 
-func (r *ClusterResource) populateComputedValues(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+func (r *ClusterResource) populateComputedValues(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
 	clusterName := data.Name.ValueString()
 
 	data.ID = types.StringValue(clusterName)
 
-	kubeconfig, err := r.provider.KubeConfig(clusterName, false)
+	// Without Kubernetes set up there is no kubeconfig yet; the credential
+	// attributes stay empty until the cluster is recreated.
+	if data.StopBeforeKubernetes.ValueBool() {
+		for _, attr := range []*types.String{&data.Kubeconfig, &data.KubeconfigPath, &data.Endpoint, &data.ClusterCaCertificate, &data.ClientCertificate, &data.ClientKey} {
+			*attr = types.StringValue("")
+		}
+	} else {
+		r.populateKubeconfig(data, diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	nodeList, err := r.provider.ListNodes(clusterName)
 	if err != nil {
-		diagnostics.AddError("Failed to get kubeconfig", err.Error())
+		diagnostics.AddError("Failed to list cluster nodes", err.Error())
 		return
 	}
-	data.Kubeconfig = types.StringValue(kubeconfig)
 
-	homeDir, err := os.UserHomeDir()
+	nodeNames := make([]NodeNameModel, 0, len(nodeList))
+	loadBalancer := ""
+	for _, node := range nodeList {
+		role, err := node.Role()
+		if err != nil {
+			diagnostics.AddError("Failed to get node role", err.Error())
+			return
+		}
+
+		// KinD registers each node under its container's hostname, which is
+		// the container name.
+		kubernetesNodeName := node.String()
+		if role == constants.ExternalLoadBalancerNodeRoleValue {
+			kubernetesNodeName = ""
+			loadBalancer = node.String()
+		}
+
+		nodeNames = append(nodeNames, NodeNameModel{
+			ContainerName:      types.StringValue(node.String()),
+			Role:               types.StringValue(role),
+			KubernetesNodeName: types.StringValue(kubernetesNodeName),
+		})
+	}
+	sort.Slice(nodeNames, func(i, j int) bool {
+		return nodeNames[i].ContainerName.ValueString() < nodeNames[j].ContainerName.ValueString()
+	})
+
+	containers := make([]string, len(nodeNames))
+	for i, node := range nodeNames {
+		containers[i] = node.ContainerName.ValueString()
+	}
+	ids, err := containerIDs(ctx, r.runtime, containers)
 	if err != nil {
-		diagnostics.AddError("Failed to get home directory", err.Error())
+		diagnostics.AddError("Failed to inspect node containers", err.Error())
 		return
 	}
-	kubeconfigPath := filepath.Join(homeDir, ".kube", "kind", "kind-"+clusterName)
-	data.KubeconfigPath = types.StringValue(kubeconfigPath)
+	for i, id := range ids {
+		nodeNames[i].ContainerID = types.StringValue(id)
+	}
 
-	var kubeconfigData map[string]interface{}
-	if err := yaml.Unmarshal([]byte(kubeconfig), &kubeconfigData); err != nil {
-		diagnostics.AddError("Failed to parse kubeconfig", err.Error())
-		return
+	nodeNamesValue, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: nodeNameAttrTypes}, nodeNames)
+	diagnostics.Append(d...)
+	data.NodeNames = nodeNamesValue
+
+	// Only clusters with several control planes have a load balancer. Failing
+	// to read its endpoint only warns, like the status attributes.
+	data.LoadBalancerEndpoint = types.StringNull()
+	if loadBalancer != "" {
+		endpoint, err := loadBalancerEndpoint(ctx, r.runtime, loadBalancer)
+		if err != nil {
+			diagnostics.AddWarning("Failed to read the load balancer endpoint", err.Error())
+		} else {
+			data.LoadBalancerEndpoint = types.StringValue(endpoint)
+		}
 	}
 
-	if clusters, ok := kubeconfigData["clusters"].([]interface{}); ok && len(clusters) > 0 {
-		if clusterData, ok := clusters[0].(map[string]interface{}); ok {
-			if clusterInfo, ok := clusterData["cluster"].(map[string]interface{}); ok {
-				if server, ok := clusterInfo["server"].(string); ok {
-					data.Endpoint = types.StringValue(server)
-				}
-				if caData, ok := clusterInfo["certificate-authority-data"].(string); ok {
-					data.ClusterCaCertificate = types.StringValue(caData)
-				}
-			}
+	// An unset node_image records the image KinD defaulted to, so the state
+	// shows what the nodes run. Failing to read it only warns.
+	if data.NodeImage.IsNull() || data.NodeImage.IsUnknown() {
+		data.NodeImage = types.StringNull()
+		image, err := usedNodeImage(ctx, r.runtime, clusterName, r.buildClusterConfig(data).Nodes)
+		if err != nil {
+			diagnostics.AddWarning("Failed to read the node image", err.Error())
+		} else {
+			data.NodeImage = types.StringValue(image)
+		}
+	}
+	data.TopologySummary = types.StringValue(r.topologySummary(data, nodeNames))
+
+	// The version the API server reports, which is what the node image
+	// actually runs regardless of its tag. It is read through the same
+	// kubeconfig as the waits, so wait_kubeconfig_override applies here too.
+	// Unreachable servers only warn, like the status attributes.
+	data.KubernetesVersion = types.StringNull()
+	if !data.StopBeforeKubernetes.ValueBool() {
+		if clientset, err := newKubernetesClientset(clientKubeconfig(data)); err != nil {
+			diagnostics.AddWarning("Failed to read Kubernetes version", err.Error())
+		} else if version, err := clientset.Discovery().ServerVersion(); err != nil {
+			diagnostics.AddWarning("Failed to read Kubernetes version", err.Error())
+		} else {
+			data.KubernetesVersion = types.StringValue(version.GitVersion)
 		}
 	}
 
-	if users, ok := kubeconfigData["users"].([]interface{}); ok && len(users) > 0 {
-		if userData, ok := users[0].(map[string]interface{}); ok {
-			if userInfo, ok := userData["user"].(map[string]interface{}); ok {
-				if certData, ok := userInfo["client-certificate-data"].(string); ok {
-					data.ClientCertificate = types.StringValue(certData)
-				}
-				if keyData, ok := userInfo["client-key-data"].(string); ok {
-					data.ClientKey = types.StringValue(keyData)
-				}
-			}
+	connection, d := types.ObjectValueFrom(ctx, connectionAttrTypes, ConnectionModel{
+		Host:                 data.Endpoint,
+		ClusterCaCertificate: types.StringValue(decodeBase64PEM(data.ClusterCaCertificate.ValueString())),
+		ClientCertificate:    types.StringValue(decodeBase64PEM(data.ClientCertificate.ValueString())),
+		ClientKey:            types.StringValue(decodeBase64PEM(data.ClientKey.ValueString())),
+	})
+	diagnostics.Append(d...)
+	data.KubernetesConnection = connection
+}
+
+// populateKubeconfig fetches the kubeconfig, writes it where configured and
+// fills the kubeconfig and credential attributes.
+func (r *ClusterResource) populateKubeconfig(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	clusterName := data.Name.ValueString()
+
+	// Refreshes fetch the kubeconfig again, picking up rotated credentials,
+	// unless export_kubeconfig_on_read is off. It is only replaced when it
+	// changed, so an unchanged cluster shows no drift.
+	kubeconfig := data.Kubeconfig.ValueString()
+	if data.Kubeconfig.IsUnknown() || kubeconfig == "" || data.ExportKubeconfigOnRead.ValueBool() {
+		current, err := r.provider.KubeConfig(clusterName, false)
+		if err != nil {
+			diagnostics.AddError("Failed to get kubeconfig", err.Error())
+			return
+		}
+		current, err = renameKubeconfigContext(current, clusterName, kubeconfigContextName(data))
+		if err != nil {
+			diagnostics.AddError("Failed to rename kubeconfig context", err.Error())
+			return
+		}
+		if current != kubeconfig {
+			kubeconfig = current
+			data.Kubeconfig = types.StringValue(kubeconfig)
 		}
 	}
 
-	if data.Endpoint.IsNull() {
-		data.Endpoint = types.StringValue("")
+	kubeconfigPath, err := kindKubeconfigPath(clusterName)
+	if err != nil {
+		diagnostics.AddError("Failed to get home directory", err.Error())
+		return
 	}
-	if data.ClusterCaCertificate.IsNull() {
-		data.ClusterCaCertificate = types.StringValue("")
+
+	if outputPath := data.KubeconfigOutputPath.ValueString(); outputPath != "" {
+		kubeconfigPath, err = expandPath(outputPath)
+		if err != nil {
+			diagnostics.AddError("Failed to resolve kubeconfig_output_path", err.Error())
+			return
+		}
+
+		if !ownedKubeconfigFile(kubeconfigPath, kubeconfigContextName(data)) {
+			diagnostics.AddAttributeError(
+				path.Root("kubeconfig_output_path"),
+				"Kubeconfig File Not Managed by the Provider",
+				fmt.Sprintf("%s exists and holds more than this cluster's context, so it is not overwritten. "+
+					"Point kubeconfig_output_path at a new file, or use merge_kubeconfig to add the cluster to a shared kubeconfig.", kubeconfigPath),
+			)
+			return
+		}
+
+		if err := writeKubeconfigFile(kubeconfigPath, kubeconfig); err != nil {
+			diagnostics.AddError("Failed to write kubeconfig", err.Error())
+			return
+		}
+	} else if !data.KubeconfigContextName.IsNull() {
+		// KinD wrote its own names to the default path.
+		if err := writeKubeconfigFile(kubeconfigPath, kubeconfig); err != nil {
+			diagnostics.AddError("Failed to write kubeconfig", err.Error())
+			return
+		}
 	}
-	if data.ClientCertificate.IsNull() {
-		data.ClientCertificate = types.StringValue("")
+	data.KubeconfigPath = types.StringValue(kubeconfigPath)
+
+	creds, err := parseKubeconfigCredentials(kubeconfig)
+	if err != nil {
+		diagnostics.AddError("Failed to parse kubeconfig", err.Error())
+		return
 	}
-	if data.ClientKey.IsNull() {
-		data.ClientKey = types.StringValue("")
+	data.Endpoint = types.StringValue(creds.Endpoint)
+	data.ClusterCaCertificate = types.StringValue(creds.ClusterCaCertificate)
+	data.ClientCertificate = types.StringValue(creds.ClientCertificate)
+	data.ClientKey = types.StringValue(creds.ClientKey)
+}
+
+// decodeBase64PEM decodes base64 kubeconfig data into PEM, the format the
+// kubernetes and helm providers expect. Undecodable input yields "".
+func decodeBase64PEM(encoded string) string {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return ""
 	}
+
+	return string(decoded)
 }
@@ -1,46 +1,277 @@
 package provider
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type ClusterResourceModel struct {
-	ID                              types.String         `tfsdk:"id"`
-	Name                            types.String         `tfsdk:"name"`
-	NodeImage                       types.String         `tfsdk:"node_image"`
-	WaitForReady                    types.Int64          `tfsdk:"wait_for_ready"`
-	WaitForNodesReady               types.Bool           `tfsdk:"wait_for_nodes_ready"`
-	Networking                      *NetworkingModel     `tfsdk:"networking"`
-	FeatureGates                    types.Map            `tfsdk:"feature_gates"`
-	RuntimeConfig                   types.Map            `tfsdk:"runtime_config"`
-	KubeadmConfigPatches            types.List           `tfsdk:"kubeadm_config_patches"`
-	KubeadmConfigPatchesJSON6902    []PatchJSON6902Model `tfsdk:"kubeadm_config_patches_json6902"`
-	ContainerdConfigPatches         types.List           `tfsdk:"containerd_config_patches"`
-	ContainerdConfigPatchesJSON6902 types.List           `tfsdk:"containerd_config_patches_json6902"`
-	Kubeconfig                      types.String         `tfsdk:"kubeconfig"`
-	KubeconfigPath                  types.String         `tfsdk:"kubeconfig_path"`
-	ClientCertificate               types.String         `tfsdk:"client_certificate"`
-	ClientKey                       types.String         `tfsdk:"client_key"`
-	ClusterCaCertificate            types.String         `tfsdk:"cluster_ca_certificate"`
-	Endpoint                        types.String         `tfsdk:"endpoint"`
-	Nodes                           []NodeModel          `tfsdk:"node"`
+	ID                                    types.String               `tfsdk:"id"`
+	Name                                  types.String               `tfsdk:"name"`
+	NodeImage                             types.String               `tfsdk:"node_image"`
+	NodeImageDigest                       types.String               `tfsdk:"node_image_digest"`
+	WaitForReady                          types.Int64                `tfsdk:"wait_for_ready"`
+	WaitForDeployments                    types.List                 `tfsdk:"wait_for_deployments"`
+	WaitForNodesReady                     types.Bool                 `tfsdk:"wait_for_nodes_ready"`
+	WaitForAPIOnly                        types.Bool                 `tfsdk:"wait_for_api_only"`
+	RevalidateAfterUpdate                 types.Bool                 `tfsdk:"revalidate_after_update"`
+	WaitKubeconfigOverride                types.String               `tfsdk:"wait_kubeconfig_override"`
+	CheckHostLimits                       types.Bool                 `tfsdk:"check_host_limits"`
+	SkipPreflight                         types.Bool                 `tfsdk:"skip_preflight"`
+	RequireNoSubnetOverlap                types.Bool                 `tfsdk:"require_no_subnet_overlap"`
+	MergeKubeconfig                       types.Bool                 `tfsdk:"merge_kubeconfig"`
+	CNIManifest                           types.String               `tfsdk:"cni_manifest"`
+	ApplyManifests                        types.List                 `tfsdk:"apply_manifests"`
+	DeleteManifestsOnDestroy              types.Bool                 `tfsdk:"delete_manifests_on_destroy"`
+	AutoApproveKubeletCerts               types.Bool                 `tfsdk:"auto_approve_kubelet_certs"`
+	InstallNodeLocalDNS                   types.Bool                 `tfsdk:"install_node_local_dns"`
+	InstallCertManager                    types.Bool                 `tfsdk:"install_cert_manager"`
+	CertManagerVersion                    types.String               `tfsdk:"cert_manager_version"`
+	CreateRetries                         types.Int64                `tfsdk:"create_retries"`
+	ManifestApplyRetries                  types.Int64                `tfsdk:"manifest_apply_retries"`
+	ManifestApplyTimeout                  types.Int64                `tfsdk:"manifest_apply_timeout"`
+	ExportLogsOnFailure                   types.Bool                 `tfsdk:"export_logs_on_failure"`
+	RetainOnFailure                       types.Bool                 `tfsdk:"retain_on_failure"`
+	AdoptExisting                         types.Bool                 `tfsdk:"adopt_existing"`
+	StopBeforeKubernetes                  types.Bool                 `tfsdk:"stop_before_kubernetes"`
+	LogExportPath                         types.String               `tfsdk:"log_export_path"`
+	Networking                            *NetworkingModel           `tfsdk:"networking"`
+	FeatureGates                          types.Map                  `tfsdk:"feature_gates"`
+	RuntimeConfig                         types.Map                  `tfsdk:"runtime_config"`
+	EnableAPIs                            types.List                 `tfsdk:"enable_apis"`
+	FailSwapOn                            types.Bool                 `tfsdk:"fail_swap_on"`
+	KubeletSystemReserved                 types.Map                  `tfsdk:"kubelet_system_reserved"`
+	KubeletKubeReserved                   types.Map                  `tfsdk:"kubelet_kube_reserved"`
+	SerializeImagePulls                   types.Bool                 `tfsdk:"serialize_image_pulls"`
+	RegistryPullQPS                       types.Int64                `tfsdk:"registry_pull_qps"`
+	RegistryBurst                         types.Int64                `tfsdk:"registry_burst"`
+	KubeProxyConntrack                    *KubeProxyConntrackModel   `tfsdk:"kube_proxy_conntrack"`
+	CoreDNS                               *CoreDNSModel              `tfsdk:"coredns"`
+	ClusterCABundle                       *ClusterCABundleModel      `tfsdk:"cluster_ca_bundle"`
+	LocalRegistry                         *LocalRegistryModel        `tfsdk:"local_registry"`
+	RBAC                                  *RBACModel                 `tfsdk:"rbac"`
+	RegistryMirrors                       []RegistryMirrorModel      `tfsdk:"registry_mirror"`
+	KubeletLogRotation                    *KubeletLogRotationModel   `tfsdk:"kubelet_log_rotation"`
+	GracefulNodeShutdown                  *GracefulNodeShutdownModel `tfsdk:"graceful_node_shutdown"`
+	DrainBeforeDelete                     *DrainBeforeDeleteModel    `tfsdk:"drain_before_delete"`
+	AuditPolicyPreset                     types.String               `tfsdk:"audit_policy_preset"`
+	AuditPolicy                           types.String               `tfsdk:"audit_policy"`
+	WatchCacheSizes                       types.Map                  `tfsdk:"watch_cache_sizes"`
+	DefaultWatchCacheSize                 types.Int64                `tfsdk:"default_watch_cache_size"`
+	APIServerShutdownDelayDuration        types.String               `tfsdk:"api_server_shutdown_delay_duration"`
+	APIServerShutdownWatchGrace           types.String               `tfsdk:"api_server_shutdown_watch_termination_grace_period"`
+	APIServerShutdownSendRetryAfter       types.Bool                 `tfsdk:"api_server_shutdown_send_retry_after"`
+	GoawayChance                          types.Float64              `tfsdk:"goaway_chance"`
+	APIServerExtraArgs                    types.Map                  `tfsdk:"apiserver_extra_args"`
+	HPASyncPeriod                         types.String               `tfsdk:"hpa_sync_period"`
+	TerminatedPodGCThreshold              types.Int64                `tfsdk:"terminated_pod_gc_threshold"`
+	KubeadmConfigPatches                  types.List                 `tfsdk:"kubeadm_config_patches"`
+	KubeadmConfigPatchesJSON6902          []PatchJSON6902Model       `tfsdk:"kubeadm_config_patches_json6902"`
+	ContainerdConfigPatches               types.List                 `tfsdk:"containerd_config_patches"`
+	ContainerdConfigPatchesJSON6902       types.List                 `tfsdk:"containerd_config_patches_json6902"`
+	ContainerdMetricsAddress              types.String               `tfsdk:"containerd_metrics_address"`
+	CRIDeviceOwnershipFromSecurityContext types.Bool                 `tfsdk:"cri_device_ownership_from_security_context"`
+	EnableImageCachePassthrough           types.Bool                 `tfsdk:"enable_image_cache_passthrough"`
+	RegistryCerts                         types.Map                  `tfsdk:"registry_certs"`
+	NodeTimezone                          types.String               `tfsdk:"node_timezone"`
+	NamespacePolicies                     []NamespacePolicyModel     `tfsdk:"namespace_policies"`
+	PriorityClasses                       []PriorityClassModel       `tfsdk:"priority_classes"`
+	EventRateLimits                       []EventRateLimitModel      `tfsdk:"event_rate_limit"`
+	APIServerTracing                      *APIServerTracingModel     `tfsdk:"api_server_tracing"`
+	DefaultRuntimeClass                   *RuntimeClassModel         `tfsdk:"default_runtime_class"`
+	LoadedImages                          types.List                 `tfsdk:"loaded_images"`
+	RestartWorkloads                      types.List                 `tfsdk:"restart_workloads"`
+	ImageArchives                         types.List                 `tfsdk:"image_archives"`
+	ExportBundlePath                      types.String               `tfsdk:"export_bundle_path"`
+	Kubeconfig                            types.String               `tfsdk:"kubeconfig"`
+	KubeconfigPath                        types.String               `tfsdk:"kubeconfig_path"`
+	KubeconfigOutputPath                  types.String               `tfsdk:"kubeconfig_output_path"`
+	KubeconfigContextName                 types.String               `tfsdk:"kubeconfig_context_name"`
+	ExportKubeconfigOnRead                types.Bool                 `tfsdk:"export_kubeconfig_on_read"`
+	ClientCertificate                     types.String               `tfsdk:"client_certificate"`
+	ClientKey                             types.String               `tfsdk:"client_key"`
+	ClusterCaCertificate                  types.String               `tfsdk:"cluster_ca_certificate"`
+	Endpoint                              types.String               `tfsdk:"endpoint"`
+	KubernetesConnection                  types.Object               `tfsdk:"kubernetes_connection"`
+	KubernetesVersion                     types.String               `tfsdk:"kubernetes_version"`
+	NodeNames                             types.List                 `tfsdk:"node_names"`
+	TopologySummary                       types.String               `tfsdk:"topology_summary"`
+	LoadBalancerEndpoint                  types.String               `tfsdk:"load_balancer_endpoint"`
+	AppliedManifests                      types.List                 `tfsdk:"applied_manifests"`
+	NodeOSInfo                            types.Map                  `tfsdk:"node_os_info"`
+	TotalCapacityCPU                      types.String               `tfsdk:"total_capacity_cpu"`
+	TotalCapacityMemory                   types.String               `tfsdk:"total_capacity_memory"`
+	TotalAllocatableCPU                   types.String               `tfsdk:"total_allocatable_cpu"`
+	TotalAllocatableMemory                types.String               `tfsdk:"total_allocatable_memory"`
+	AllocatedPodIPs                       types.Int64                `tfsdk:"allocated_pod_ips"`
+	AllocatedServiceIPs                   types.Int64                `tfsdk:"allocated_service_ips"`
+	DetectedIPFamily                      types.String               `tfsdk:"detected_ip_family"`
+	Namespaces                            types.List                 `tfsdk:"namespaces"`
+	KubeadmConfig                         types.String               `tfsdk:"kubeadm_config"`
+	NodeTaints                            types.Map                  `tfsdk:"node_taints"`
+	Timeouts                              timeouts.Value             `tfsdk:"timeouts"`
+	Nodes                                 []NodeModel                `tfsdk:"node"`
+}
+
+type ConnectionModel struct {
+	Host                 types.String `tfsdk:"host"`
+	ClusterCaCertificate types.String `tfsdk:"cluster_ca_certificate"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+}
+
+var connectionAttrTypes = map[string]attr.Type{
+	"host":                   types.StringType,
+	"cluster_ca_certificate": types.StringType,
+	"client_certificate":     types.StringType,
+	"client_key":             types.StringType,
+}
+
+type NodeOSInfoModel struct {
+	OSImage                 types.String `tfsdk:"os_image"`
+	KernelVersion           types.String `tfsdk:"kernel_version"`
+	ContainerRuntimeVersion types.String `tfsdk:"container_runtime_version"`
+}
+
+type EventRateLimitModel struct {
+	Type      types.String `tfsdk:"type"`
+	QPS       types.Int64  `tfsdk:"qps"`
+	Burst     types.Int64  `tfsdk:"burst"`
+	CacheSize types.Int64  `tfsdk:"cache_size"`
+}
+
+type APIServerTracingModel struct {
+	Endpoint     types.String  `tfsdk:"endpoint"`
+	SamplingRate types.Float64 `tfsdk:"sampling_rate"`
+}
+
+type RegistryMirrorModel struct {
+	Endpoint types.String `tfsdk:"endpoint"`
+	Mirrors  types.List   `tfsdk:"mirrors"`
+}
+
+type RBACModel struct {
+	Manifests types.List `tfsdk:"manifests"`
+}
+
+type LocalRegistryModel struct {
+	Name  types.String `tfsdk:"name"`
+	Port  types.Int64  `tfsdk:"port"`
+	Image types.String `tfsdk:"image"`
+}
+
+type ClusterCABundleModel struct {
+	PEM           types.String `tfsdk:"pem"`
+	ConfigMapName types.String `tfsdk:"config_map_name"`
+	Namespaces    types.List   `tfsdk:"namespaces"`
+}
+
+type CoreDNSModel struct {
+	ForwardTo types.List  `tfsdk:"forward_to"`
+	CacheTTL  types.Int64 `tfsdk:"cache_ttl"`
+}
+
+type KubeProxyConntrackModel struct {
+	MaxPerCore            types.Int64  `tfsdk:"max_per_core"`
+	Min                   types.Int64  `tfsdk:"min"`
+	TCPEstablishedTimeout types.String `tfsdk:"tcp_established_timeout"`
+	TCPCloseWaitTimeout   types.String `tfsdk:"tcp_close_wait_timeout"`
+}
+
+type KubeletLogRotationModel struct {
+	ContainerLogMaxSize         types.String `tfsdk:"container_log_max_size"`
+	ContainerLogMaxFiles        types.Int64  `tfsdk:"container_log_max_files"`
+	ImageGCHighThresholdPercent types.Int64  `tfsdk:"image_gc_high_threshold_percent"`
+	ImageGCLowThresholdPercent  types.Int64  `tfsdk:"image_gc_low_threshold_percent"`
+}
+
+type DrainBeforeDeleteModel struct {
+	RespectPDBs  types.Bool  `tfsdk:"respect_pdbs"`
+	DrainTimeout types.Int64 `tfsdk:"drain_timeout"`
+}
+
+type GracefulNodeShutdownModel struct {
+	GracePeriod             types.String `tfsdk:"grace_period"`
+	CriticalPodsGracePeriod types.String `tfsdk:"critical_pods_grace_period"`
+}
+
+type PriorityClassModel struct {
+	Name             types.String `tfsdk:"name"`
+	Value            types.Int64  `tfsdk:"value"`
+	GlobalDefault    types.Bool   `tfsdk:"global_default"`
+	PreemptionPolicy types.String `tfsdk:"preemption_policy"`
+}
+
+type NamespacePolicyModel struct {
+	Namespace           types.String `tfsdk:"namespace"`
+	ResourceQuota       types.Map    `tfsdk:"resource_quota"`
+	LimitDefault        types.Map    `tfsdk:"limit_default"`
+	LimitDefaultRequest types.Map    `tfsdk:"limit_default_request"`
+	LimitMax            types.Map    `tfsdk:"limit_max"`
+	LimitMin            types.Map    `tfsdk:"limit_min"`
+}
+
+type RuntimeClassModel struct {
+	Name      types.String `tfsdk:"name"`
+	Handler   types.String `tfsdk:"handler"`
+	Preferred types.Bool   `tfsdk:"preferred"`
+}
+
+type NodeNameModel struct {
+	ContainerName      types.String `tfsdk:"container_name"`
+	ContainerID        types.String `tfsdk:"container_id"`
+	Role               types.String `tfsdk:"role"`
+	KubernetesNodeName types.String `tfsdk:"kubernetes_node_name"`
+}
+
+var nodeNameAttrTypes = map[string]attr.Type{
+	"container_name":       types.StringType,
+	"container_id":         types.StringType,
+	"role":                 types.StringType,
+	"kubernetes_node_name": types.StringType,
+}
+
+type AppliedManifestModel struct {
+	APIVersion types.String `tfsdk:"api_version"`
+	Kind       types.String `tfsdk:"kind"`
+	Namespace  types.String `tfsdk:"namespace"`
+	Name       types.String `tfsdk:"name"`
+}
+
+var appliedManifestAttrTypes = map[string]attr.Type{
+	"api_version": types.StringType,
+	"kind":        types.StringType,
+	"namespace":   types.StringType,
+	"name":        types.StringType,
+}
+
+var nodeOSInfoAttrTypes = map[string]attr.Type{
+	"os_image":                  types.StringType,
+	"kernel_version":            types.StringType,
+	"container_runtime_version": types.StringType,
 }
 
 type NetworkingModel struct {
-	IPFamily          types.String `tfsdk:"ip_family"`
-	APIServerPort     types.Int64  `tfsdk:"api_server_port"`
-	APIServerAddress  types.String `tfsdk:"api_server_address"`
-	PodSubnet         types.String `tfsdk:"pod_subnet"`
-	ServiceSubnet     types.String `tfsdk:"service_subnet"`
-	DisableDefaultCNI types.Bool   `tfsdk:"disable_default_cni"`
-	KubeProxyMode     types.String `tfsdk:"kube_proxy_mode"`
-	DNSSearch         types.List   `tfsdk:"dns_search"`
+	IPFamily             types.String `tfsdk:"ip_family"`
+	APIServerPort        types.Int64  `tfsdk:"api_server_port"`
+	APIServerAddress     types.String `tfsdk:"api_server_address"`
+	PodSubnet            types.String `tfsdk:"pod_subnet"`
+	ServiceSubnet        types.String `tfsdk:"service_subnet"`
+	DisableDefaultCNI    types.Bool   `tfsdk:"disable_default_cni"`
+	KubeProxyMode        types.String `tfsdk:"kube_proxy_mode"`
+	DNSSearch            types.List   `tfsdk:"dns_search"`
+	ServiceNodePortRange types.String `tfsdk:"service_node_port_range"`
 }
 
 type NodeModel struct {
 	Role                         types.String         `tfsdk:"role"`
 	Image                        types.String         `tfsdk:"image"`
 	Labels                       types.Map            `tfsdk:"labels"`
+	ExtraLabels                  types.Map            `tfsdk:"extra_labels"`
+	ExtraArgs                    types.List           `tfsdk:"extra_args"`
+	Ulimits                      types.Map            `tfsdk:"ulimits"`
+	KubeletExtraArgs             types.Map            `tfsdk:"kubelet_extra_args"`
 	ExtraMounts                  []MountModel         `tfsdk:"extra_mounts"`
 	ExtraPortMappings            []PortMappingModel   `tfsdk:"extra_port_mappings"`
 	KubeadmConfigPatches         types.List           `tfsdk:"kubeadm_config_patches"`
@@ -69,7 +300,89 @@ type PatchJSON6902Model struct {
 	Patch   types.String `tfsdk:"patch"`
 }
 
+type ClusterDataSourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Kubeconfig           types.String `tfsdk:"kubeconfig"`
+	KubeconfigPath       types.String `tfsdk:"kubeconfig_path"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	ClusterCaCertificate types.String `tfsdk:"cluster_ca_certificate"`
+	Endpoint             types.String `tfsdk:"endpoint"`
+}
+
+type ClusterExistsDataSourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Exists types.Bool   `tfsdk:"exists"`
+}
+
+type ClusterNodesDataSourceModel struct {
+	ID    types.String           `tfsdk:"id"`
+	Name  types.String           `tfsdk:"name"`
+	Nodes []ClusterNodeDataModel `tfsdk:"nodes"`
+}
+
+type ClusterNodeDataModel struct {
+	Name         types.String `tfsdk:"name"`
+	Role         types.String `tfsdk:"role"`
+	ContainerID  types.String `tfsdk:"container_id"`
+	InternalIP   types.String `tfsdk:"internal_ip"`
+	InternalIPv6 types.String `tfsdk:"internal_ipv6"`
+}
+
+type KubeconfigDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Internal   types.Bool   `tfsdk:"internal"`
+	Kubeconfig types.String `tfsdk:"kubeconfig"`
+}
+
 type ClustersDataSourceModel struct {
-	ID       types.String   `tfsdk:"id"`
-	Clusters []types.String `tfsdk:"clusters"`
+	ID         types.String   `tfsdk:"id"`
+	NamePrefix types.String   `tfsdk:"name_prefix"`
+	NameRegex  types.String   `tfsdk:"name_regex"`
+	Clusters   []types.String `tfsdk:"clusters"`
+}
+
+type NodeImageDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	KubernetesVersion types.String `tfsdk:"kubernetes_version"`
+	AllowUnpinned     types.Bool   `tfsdk:"allow_unpinned"`
+	Image             types.String `tfsdk:"image"`
+	KindVersion       types.String `tfsdk:"kind_version"`
+}
+
+type ProviderVersionDataSourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ProviderVersion    types.String `tfsdk:"provider_version"`
+	KindVersion        types.String `tfsdk:"kind_version"`
+	DefaultNodeImage   types.String `tfsdk:"default_node_image"`
+	NodeImages         types.List   `tfsdk:"node_images"`
+	KubernetesVersions types.List   `tfsdk:"kubernetes_versions"`
+}
+
+type DefaultNodeImageDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Image             types.String `tfsdk:"image"`
+	KubernetesVersion types.String `tfsdk:"kubernetes_version"`
+	KindVersion       types.String `tfsdk:"kind_version"`
+}
+
+type PatchValidationDataSourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Patch types.String `tfsdk:"patch"`
+	Type  types.String `tfsdk:"type"`
+	Valid types.Bool   `tfsdk:"valid"`
+	Error types.String `tfsdk:"error"`
+}
+
+type DockerNetworkDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Subnet      types.String `tfsdk:"subnet"`
+	Gateway     types.String `tfsdk:"gateway"`
+	IPv6Subnet  types.String `tfsdk:"ipv6_subnet"`
+	IPv6Gateway types.String `tfsdk:"ipv6_gateway"`
+	Subnets     types.List   `tfsdk:"subnets"`
 }
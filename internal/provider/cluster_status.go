@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// configuredIPFamily returns the IP family requested in the configuration,
+// defaulting to ipv4 like KinD.
+func configuredIPFamily(data *ClusterResourceModel) string {
+	if data.Networking != nil && !data.Networking.IPFamily.IsNull() && !data.Networking.IPFamily.IsUnknown() && data.Networking.IPFamily.ValueString() != "" {
+		return data.Networking.IPFamily.ValueString()
+	}
+
+	return string(v1alpha4.IPv4Family)
+}
+
+// The ConfigMap and key kubeadm stores the cluster's ClusterConfiguration in.
+const (
+	kubeadmConfigConfigMap         = "kubeadm-config"
+	kubeadmClusterConfigurationKey = "ClusterConfiguration"
+)
+
+// populateClusterStatus reads node status from the running cluster and fills
+// the computed status attributes. It is best-effort: failures are reported as
+// warnings and leave the attributes empty, since the cluster itself is usable.
+func (r *ClusterResource) populateClusterStatus(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	data.NodeOSInfo = types.MapNull(types.ObjectType{AttrTypes: nodeOSInfoAttrTypes})
+	data.TotalCapacityCPU = types.StringNull()
+	data.TotalCapacityMemory = types.StringNull()
+	data.TotalAllocatableCPU = types.StringNull()
+	data.TotalAllocatableMemory = types.StringNull()
+	data.AllocatedPodIPs = types.Int64Null()
+	data.AllocatedServiceIPs = types.Int64Null()
+	data.Namespaces = types.ListNull(types.StringType)
+	data.NodeTaints = types.MapNull(types.ListType{ElemType: types.StringType})
+	data.KubeadmConfig = types.StringNull()
+	// Detection below replaces this; on failure the configured family is kept
+	// alongside the warning.
+	data.DetectedIPFamily = types.StringValue(configuredIPFamily(data))
+	if data.StopBeforeKubernetes.ValueBool() {
+		return
+	}
+
+	clientset, err := newKubernetesClientset(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddWarning("Failed to read cluster status", err.Error())
+		return
+	}
+
+	// Namespaces are independent of node and pod status, so a failure here
+	// does not stop the reads below.
+	if namespaces, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{}); err != nil {
+		diagnostics.AddWarning("Failed to read cluster status", "Could not list namespaces: "+err.Error())
+	} else {
+		names := make([]string, 0, len(namespaces.Items))
+		for _, namespace := range namespaces.Items {
+			names = append(names, namespace.Name)
+		}
+		slices.Sort(names)
+
+		value, d := types.ListValueFrom(ctx, types.StringType, names)
+		diagnostics.Append(d...)
+		data.Namespaces = value
+	}
+
+	// The ClusterConfiguration kubeadm recorded at init, with every patch
+	// applied, unlike the configuration the provider generated.
+	if configMap, err := clientset.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(ctx, kubeadmConfigConfigMap, metav1.GetOptions{}); err != nil {
+		diagnostics.AddWarning("Failed to read cluster status", "Could not read the kubeadm-config ConfigMap: "+err.Error())
+	} else {
+		data.KubeadmConfig = types.StringValue(configMap.Data[kubeadmClusterConfigurationKey])
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		diagnostics.AddWarning("Failed to read cluster status", "Could not list nodes: "+err.Error())
+		return
+	}
+
+	osInfo := make(map[string]NodeOSInfoModel, len(nodes.Items))
+	taints := make(map[string][]string, len(nodes.Items))
+	var capacityCPU, capacityMemory, allocatableCPU, allocatableMemory resource.Quantity
+	for _, node := range nodes.Items {
+		osInfo[node.Name] = NodeOSInfoModel{
+			OSImage:                 types.StringValue(node.Status.NodeInfo.OSImage),
+			KernelVersion:           types.StringValue(node.Status.NodeInfo.KernelVersion),
+			ContainerRuntimeVersion: types.StringValue(node.Status.NodeInfo.ContainerRuntimeVersion),
+		}
+
+		// Taints are reported like kubectl shows them, key[=value]:Effect.
+		taints[node.Name] = make([]string, 0, len(node.Spec.Taints))
+		for _, taint := range node.Spec.Taints {
+			taints[node.Name] = append(taints[node.Name], taint.ToString())
+		}
+		slices.Sort(taints[node.Name])
+
+		capacityCPU.Add(*node.Status.Capacity.Cpu())
+		capacityMemory.Add(*node.Status.Capacity.Memory())
+		allocatableCPU.Add(*node.Status.Allocatable.Cpu())
+		allocatableMemory.Add(*node.Status.Allocatable.Memory())
+	}
+
+	data.TotalCapacityCPU = types.StringValue(capacityCPU.String())
+	data.TotalCapacityMemory = types.StringValue(capacityMemory.String())
+	data.TotalAllocatableCPU = types.StringValue(allocatableCPU.String())
+	data.TotalAllocatableMemory = types.StringValue(allocatableMemory.String())
+
+	value, d := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: nodeOSInfoAttrTypes}, osInfo)
+	diagnostics.Append(d...)
+	data.NodeOSInfo = value
+
+	taintsValue, d := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, taints)
+	diagnostics.Append(d...)
+	data.NodeTaints = taintsValue
+
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		diagnostics.AddWarning("Failed to read cluster status", "Could not list pods: "+err.Error())
+		return
+	}
+
+	var podIPs int64
+	var hasIPv4, hasIPv6 bool
+	for _, pod := range pods.Items {
+		// Host-network pods report the node IP and do not consume the pod subnet.
+		if pod.Spec.HostNetwork {
+			continue
+		}
+		podIPs += int64(len(pod.Status.PodIPs))
+
+		for _, podIP := range pod.Status.PodIPs {
+			if ip := net.ParseIP(podIP.IP); ip != nil {
+				if ip.To4() != nil {
+					hasIPv4 = true
+				} else {
+					hasIPv6 = true
+				}
+			}
+		}
+	}
+
+	switch {
+	case hasIPv4 && hasIPv6:
+		data.DetectedIPFamily = types.StringValue(string(v1alpha4.DualStackFamily))
+	case hasIPv6:
+		data.DetectedIPFamily = types.StringValue(string(v1alpha4.IPv6Family))
+	case hasIPv4:
+		data.DetectedIPFamily = types.StringValue(string(v1alpha4.IPv4Family))
+	default:
+		diagnostics.AddWarning(
+			"Failed to detect IP family",
+			"No pod has an IP yet, reporting the configured IP family in detected_ip_family.",
+		)
+	}
+
+	services, err := clientset.CoreV1().Services(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		diagnostics.AddWarning("Failed to read cluster status", "Could not list services: "+err.Error())
+		return
+	}
+
+	var serviceIPs int64
+	for _, service := range services.Items {
+		for _, ip := range service.Spec.ClusterIPs {
+			if ip != "" && ip != corev1.ClusterIPNone {
+				serviceIPs++
+			}
+		}
+	}
+
+	data.AllocatedPodIPs = types.Int64Value(podIPs)
+	data.AllocatedServiceIPs = types.Int64Value(serviceIPs)
+}
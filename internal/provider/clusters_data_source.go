@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+var _ datasource.DataSource = &ClustersDataSource{}
+
+type ClustersDataSource struct {
+	provider *cluster.Provider
+}
+
+func NewClustersDataSource() datasource.DataSource {
+	return &ClustersDataSource{}
+}
+
+func (d *ClustersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clusters"
+}
+
+func (d *ClustersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List all KinD clusters, optionally only those whose name matches a prefix or regular expression.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier.",
+				Computed:    true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Only list clusters whose name starts with this prefix.",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only list clusters whose name matches this regular expression (Go RE2 syntax, unanchored). Combined with name_prefix, both must match.",
+				Optional:    true,
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			"clusters": schema.ListAttribute{
+				Description: "List of cluster names, filtered by name_prefix and name_regex.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *ClustersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KindProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *KindProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.provider = providerData.Provider
+}
+
+func (d *ClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClustersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if pattern := data.NameRegex.ValueString(); pattern != "" {
+		var err error
+		if nameRegex, err = regexp.Compile(pattern); err != nil {
+			resp.Diagnostics.AddError("Invalid name_regex", err.Error())
+			return
+		}
+	}
+
+	clusters, err := d.provider.List()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list clusters", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue("kind-clusters")
+	data.Clusters = make([]types.String, 0, len(clusters))
+	for _, c := range clusters {
+		if !strings.HasPrefix(c, data.NamePrefix.ValueString()) || (nameRegex != nil && !nameRegex.MatchString(c)) {
+			continue
+		}
+		data.Clusters = append(data.Clusters, types.StringValue(c))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
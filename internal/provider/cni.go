@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// isManifestURL reports whether a manifest source is an http(s) URL rather
+// than a local file.
+func isManifestURL(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
+
+// loadManifest reads a manifest from an http(s) URL or a local file.
+func loadManifest(ctx context.Context, source string) ([]byte, error) {
+	if isManifestURL(source) {
+		return fetchManifest(ctx, source)
+	}
+
+	file, err := expandPath(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(file)
+}
+
+// installCNI server-side applies the cni_manifest objects, the same way as
+// apply_manifests, and waits, within wait_for_ready, for the DaemonSets and
+// Deployments it creates to be ready.
+func (r *ClusterResource) installCNI(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	source := data.CNIManifest.ValueString()
+
+	manifest, err := loadManifest(ctx, source)
+	if err != nil {
+		diagnostics.AddError("Failed to install CNI", fmt.Sprintf("Reading %s failed: %s", source, err))
+		return
+	}
+
+	objects, errs := parseManifestDocuments(source, string(manifest))
+	for _, err := range errs {
+		diagnostics.AddError("Failed to install CNI", fmt.Sprintf("Parsing %s failed: %s", source, err))
+	}
+	if len(errs) > 0 {
+		return
+	}
+
+	client, err := newManifestClient(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddError("Failed to install CNI", err.Error())
+		return
+	}
+
+	applier := newManifestApplier(data)
+	for _, m := range objects {
+		err := applier.run(ctx, func(ctx context.Context) error {
+			return client.apply(ctx, m.obj)
+		})
+		if err != nil {
+			diagnostics.AddError("Failed to install CNI", fmt.Sprintf("Applying %s, %s %s failed: %s", m.source, m.obj.GetKind(), m.obj.GetName(), err))
+			return
+		}
+	}
+
+	clientset, err := newKubernetesClientset(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddError("Failed to install CNI", err.Error())
+		return
+	}
+
+	// Namespaces were defaulted when the objects were applied.
+	timeout := time.Duration(data.WaitForReady.ValueInt64()) * time.Second
+	var deployments []string
+	for _, m := range objects {
+		switch m.obj.GetKind() {
+		case "Deployment":
+			deployments = append(deployments, m.obj.GetNamespace()+"/"+m.obj.GetName())
+		case "DaemonSet":
+			if err := waitForDaemonSetReady(ctx, clientset, m.obj.GetNamespace(), m.obj.GetName(), timeout); err != nil {
+				diagnostics.AddError("CNI not ready", err.Error())
+				return
+			}
+		}
+	}
+
+	if len(deployments) > 0 {
+		if err := waitForDeploymentsRolledOut(ctx, clientKubeconfig(data), deployments, timeout); err != nil {
+			diagnostics.AddError("CNI not ready", err.Error())
+		}
+	}
+}
+
+// waitForDaemonSetReady polls a DaemonSet until it has observed its latest
+// spec and has a ready, updated pod on every node it is scheduled to. A
+// DaemonSet whose node selector matches no node is ready once observed.
+func waitForDaemonSetReady(ctx context.Context, clientset kubernetes.Interface, namespace, name string, timeout time.Duration) error {
+	ticker := time.NewTicker(defaultNodeReadyWaiter.pollInterval)
+	defer ticker.Stop()
+
+	timeoutCh := time.After(timeout)
+	status := "the DaemonSet was not found"
+
+	for {
+		ds, err := clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			desired := ds.Status.DesiredNumberScheduled
+			if ds.Status.ObservedGeneration >= ds.Generation &&
+				ds.Status.NumberReady == desired && ds.Status.UpdatedNumberScheduled == desired {
+				return nil
+			}
+			status = fmt.Sprintf("%d/%d pods ready, %d updated", ds.Status.NumberReady, desired, ds.Status.UpdatedNumberScheduled)
+		} else {
+			status = err.Error()
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("DaemonSet %s/%s is not ready: %s: %w", namespace, name, status, ctx.Err())
+		case <-timeoutCh:
+			return fmt.Errorf("DaemonSet %s/%s is not ready after %s: %s", namespace, name, timeout, status)
+		case <-ticker.C:
+		}
+	}
+}
+
+// validateCNIManifest warns when a CNI manifest is installed next to kindnet.
+// A manifest file that is missing is reported by Create, since it may be
+// generated during the same apply.
+func validateCNIManifest(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.CNIManifest.IsNull() || data.CNIManifest.IsUnknown() {
+		return
+	}
+
+	if data.Networking == nil || data.Networking.DisableDefaultCNI.IsUnknown() || !data.Networking.DisableDefaultCNI.ValueBool() {
+		diagnostics.AddAttributeWarning(
+			path.Root("cni_manifest"),
+			"Default CNI Not Disabled",
+			"cni_manifest is set but networking.disable_default_cni is not, so the CNI is installed next to kindnet.",
+		)
+	}
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+// containerRuntimes are the node provider CLIs KinD supports, the values
+// KIND_EXPERIMENTAL_PROVIDER accepts: finch and nerdctl.lima are nerdctl
+// compatible. Each is also the CLI used for node container operations that the
+// kind library does not expose.
+var containerRuntimes = []string{"docker", "podman", "nerdctl", "finch", "nerdctl.lima"}
+
+// kindExperimentalProviderEnv selects the node provider in the kind CLI. The
+// kind library leaves honouring it to its callers.
+const kindExperimentalProviderEnv = "KIND_EXPERIMENTAL_PROVIDER"
+
+// clusterProviderOptions returns the kind provider options selecting the
+// given container runtime. An empty runtime leaves the choice to KinD's
+// auto-detection.
+func clusterProviderOptions(runtime string) ([]cluster.ProviderOption, error) {
+	switch runtime {
+	case "":
+		return nil, nil
+	case "docker":
+		return []cluster.ProviderOption{cluster.ProviderWithDocker()}, nil
+	case "podman":
+		return []cluster.ProviderOption{cluster.ProviderWithPodman()}, nil
+	case "nerdctl", "finch", "nerdctl.lima":
+		return []cluster.ProviderOption{cluster.ProviderWithNerdctl(runtime)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported container runtime %q, must be one of: %s", runtime, strings.Join(containerRuntimes, ", "))
+	}
+}
+
+// experimentalProviderRuntime returns the container runtime selected by
+// KIND_EXPERIMENTAL_PROVIDER, the same way the kind CLI reads it, or an empty
+// string when it is unset. Values the kind CLI ignores are an error.
+func experimentalProviderRuntime() (string, error) {
+	runtime := os.Getenv(kindExperimentalProviderEnv)
+	if runtime == "" || slices.Contains(containerRuntimes, runtime) {
+		return runtime, nil
+	}
+
+	return "", fmt.Errorf("unknown value %q for %s, falling back to auto-detection", runtime, kindExperimentalProviderEnv)
+}
+
+// detectContainerRuntime returns the runtime CLI KinD's auto-detection would
+// pick, checking the same runtimes in the same order. It falls back to docker
+// so that errors name the runtime users most likely expect.
+func detectContainerRuntime() string {
+	for _, runtime := range []string{"docker", "nerdctl", "podman"} {
+		if _, err := exec.LookPath(runtime); err == nil {
+			return runtime
+		}
+	}
+
+	return "docker"
+}
+
+// runContainerCommand runs the container runtime CLI and returns its trimmed
+// output. Errors carry the command output so failures are actionable.
+func runContainerCommand(ctx context.Context, runtime string, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, runtime, args...).CombinedOutput()
+	output := strings.TrimSpace(string(out))
+	if err != nil {
+		return output, fmt.Errorf("%s %s failed: %w: %s", runtime, strings.Join(args, " "), err, output)
+	}
+
+	return output, nil
+}
+
+// nodeContainerNames returns the container names KinD assigns to the given
+// nodes, in the same order. It mirrors KinD's naming: the first node of a role
+// is <cluster>-<role> and later ones get a numeric suffix starting at 2.
+func nodeContainerNames(clusterName string, nodes []v1alpha4.Node) []string {
+	counter := make(map[v1alpha4.NodeRole]int)
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		counter[node.Role]++
+		suffix := ""
+		if counter[node.Role] > 1 {
+			suffix = fmt.Sprintf("%d", counter[node.Role])
+		}
+		names[i] = fmt.Sprintf("%s-%s%s", clusterName, node.Role, suffix)
+	}
+
+	return names
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// criDeviceOwnershipKey is the CRI setting making devices passed to a
+// container owned by the pod's runAsUser and runAsGroup instead of root. The
+// node images use containerd config version 2, where it lives in the
+// io.containerd.grpc.v1.cri plugin.
+const criDeviceOwnershipKey = "device_ownership_from_security_context"
+
+// criDefaultRuntimeKey is the CRI containerd setting naming the runtime
+// handler pods without a RuntimeClass run with.
+const criDefaultRuntimeKey = "default_runtime_name"
+
+// buildContainerdConfigPatches renders the typed containerd settings into TOML
+// merge patches for the node containerd configuration.
+func buildContainerdConfigPatches(data *ClusterResourceModel) []string {
+	var patches []string
+
+	if !data.ContainerdMetricsAddress.IsNull() && data.ContainerdMetricsAddress.ValueString() != "" {
+		patches = append(patches, fmt.Sprintf("[metrics]\n  address = %q\n", data.ContainerdMetricsAddress.ValueString()))
+	}
+
+	if data.EnableImageCachePassthrough.ValueBool() {
+		patches = append(patches, imageCacheContainerdPatch)
+	}
+
+	if rc := data.DefaultRuntimeClass; rc != nil && rc.Preferred.ValueBool() {
+		patches = append(patches, fmt.Sprintf("[plugins.%q.containerd]\n  %s = %q\n",
+			criPluginNames[0], criDefaultRuntimeKey, rc.Handler.ValueString()))
+	}
+
+	if !data.CRIDeviceOwnershipFromSecurityContext.IsNull() {
+		patches = append(patches, fmt.Sprintf("[plugins.%q]\n  %s = %t\n",
+			criPluginNames[0], criDeviceOwnershipKey, data.CRIDeviceOwnershipFromSecurityContext.ValueBool()))
+	}
+
+	return patches
+}
+
+// builtinRuntimeHandlers are the containerd runtime handlers configured in
+// the kindest/node images out of the box.
+var builtinRuntimeHandlers = []string{"runc", "test-handler"}
+
+// criPluginNames are the CRI plugin keys used by containerd config versions 2
+// and 3 respectively.
+var criPluginNames = []string{"io.containerd.grpc.v1.cri", "io.containerd.cri.v1.runtime"}
+
+// containerdRuntimeHandlers returns the runtime handlers available on the
+// nodes: the built-in ones plus any declared in the TOML config patches.
+func containerdRuntimeHandlers(patches []string) ([]string, error) {
+	handlers := append([]string{}, builtinRuntimeHandlers...)
+
+	for _, patch := range patches {
+		var doc map[string]interface{}
+		if _, err := toml.Decode(patch, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse containerd config patch: %w", err)
+		}
+
+		plugins, _ := doc["plugins"].(map[string]interface{})
+		for _, name := range criPluginNames {
+			cri, _ := plugins[name].(map[string]interface{})
+			containerd, _ := cri["containerd"].(map[string]interface{})
+			runtimes, _ := containerd["runtimes"].(map[string]interface{})
+			for handler := range runtimes {
+				handlers = append(handlers, handler)
+			}
+		}
+	}
+
+	return handlers, nil
+}
+
+// containerdDefaultRuntime returns the default runtime handler the TOML
+// config patches set, if any. Later patches win, as when KinD merges them.
+func containerdDefaultRuntime(patches []string) (string, bool) {
+	var name string
+	var found bool
+	for _, patch := range patches {
+		var doc map[string]interface{}
+		if _, err := toml.Decode(patch, &doc); err != nil {
+			continue
+		}
+
+		plugins, _ := doc["plugins"].(map[string]interface{})
+		for _, plugin := range criPluginNames {
+			cri, _ := plugins[plugin].(map[string]interface{})
+			containerd, _ := cri["containerd"].(map[string]interface{})
+			if value, ok := containerd[criDefaultRuntimeKey].(string); ok {
+				name, found = value, true
+			}
+		}
+	}
+
+	return name, found
+}
+
+// validateCRIDeviceOwnership rejects containerd_config_patches setting
+// device_ownership_from_security_context to something else than
+// cri_device_ownership_from_security_context, since the patch applied last
+// would silently win.
+func validateCRIDeviceOwnership(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.CRIDeviceOwnershipFromSecurityContext.IsNull() || data.CRIDeviceOwnershipFromSecurityContext.IsUnknown() || data.ContainerdConfigPatches.IsUnknown() {
+		return
+	}
+
+	want := data.CRIDeviceOwnershipFromSecurityContext.ValueBool()
+	for _, patch := range listStringValues(data.ContainerdConfigPatches) {
+		var doc map[string]interface{}
+		if _, err := toml.Decode(patch, &doc); err != nil {
+			// Invalid patches are reported by validateConfigPatches.
+			continue
+		}
+
+		plugins, _ := doc["plugins"].(map[string]interface{})
+		for _, name := range criPluginNames {
+			cri, _ := plugins[name].(map[string]interface{})
+			if value, ok := cri[criDeviceOwnershipKey].(bool); ok && value != want {
+				diagnostics.AddAttributeError(
+					path.Root("cri_device_ownership_from_security_context"),
+					"Conflicting Containerd Setting",
+					fmt.Sprintf("containerd_config_patches sets %s = %t in %s, which contradicts cri_device_ownership_from_security_context = %t. Remove it from the patch.",
+						criDeviceOwnershipKey, value, name, want),
+				)
+				return
+			}
+		}
+	}
+}
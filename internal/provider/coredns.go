@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The CoreDNS ConfigMap and Deployment kubeadm installs.
+const (
+	corednsName         = "coredns"
+	corednsCorefileKey  = "Corefile"
+	corednsRestartedKey = "kubectl.kubernetes.io/restartedAt"
+)
+
+// dnsUpstreamSchemes are the transport prefixes the CoreDNS forward plugin
+// accepts in front of an upstream address.
+var dnsUpstreamSchemes = []string{"dns://", "tls://"}
+
+var (
+	corednsForwardRegexp = regexp.MustCompile(`(?m)^(\s*)forward\s+\.\s+[^{\n]*?(\s*\{)?$`)
+	corednsCacheRegexp   = regexp.MustCompile(`(?m)^(\s*)cache(\s+[0-9]+)?(\s*\{)?$`)
+)
+
+// validateDNSUpstream checks that an upstream is an IP address, optionally
+// with a port and a dns:// or tls:// scheme.
+func validateDNSUpstream(upstream string) error {
+	address := upstream
+	for _, scheme := range dnsUpstreamSchemes {
+		address = strings.TrimPrefix(address, scheme)
+	}
+
+	host := address
+	if h, port, err := net.SplitHostPort(address); err == nil {
+		if _, err := net.LookupPort("udp", port); err != nil {
+			return fmt.Errorf("invalid port %q", port)
+		}
+		host = h
+	}
+
+	if net.ParseIP(host) == nil {
+		return fmt.Errorf("%q is not an IP address", host)
+	}
+
+	return nil
+}
+
+// patchCorefile rewrites the upstreams of the root zone's forward plugin and
+// the TTL of its cache plugin, keeping their option blocks.
+func patchCorefile(corefile string, settings *CoreDNSModel) (string, error) {
+	if upstreams := listStringValues(settings.ForwardTo); len(upstreams) > 0 {
+		if !corednsForwardRegexp.MatchString(corefile) {
+			return "", fmt.Errorf("the Corefile has no forward plugin for the root zone")
+		}
+		corefile = corednsForwardRegexp.ReplaceAllString(corefile, "${1}forward . "+strings.Join(upstreams, " ")+"${2}")
+	}
+
+	if !settings.CacheTTL.IsNull() {
+		if !corednsCacheRegexp.MatchString(corefile) {
+			return "", fmt.Errorf("the Corefile has no cache plugin")
+		}
+		corefile = corednsCacheRegexp.ReplaceAllString(corefile, fmt.Sprintf("${1}cache %d${3}", settings.CacheTTL.ValueInt64()))
+	}
+
+	return corefile, nil
+}
+
+// coreDNSChanged reports whether the planned coredns block has settings to
+// apply that differ from the current ones.
+func coreDNSChanged(planned, current *CoreDNSModel) bool {
+	if planned == nil {
+		return false
+	}
+	if current == nil {
+		return true
+	}
+
+	return !planned.ForwardTo.Equal(current.ForwardTo) || !planned.CacheTTL.Equal(current.CacheTTL)
+}
+
+// configureCoreDNS applies the coredns block to the CoreDNS ConfigMap and
+// restarts CoreDNS so the change takes effect without waiting for its reload
+// plugin.
+func configureCoreDNS(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	clientset, err := newKubernetesClientset(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddError("Failed to configure CoreDNS", err.Error())
+		return
+	}
+
+	if err := applyCoreDNSSettings(ctx, clientset, data.CoreDNS); err != nil {
+		diagnostics.AddError("Failed to configure CoreDNS", err.Error())
+	}
+}
+
+// applyCoreDNSSettings patches the Corefile and restarts the CoreDNS
+// Deployment.
+func applyCoreDNSSettings(ctx context.Context, clientset kubernetes.Interface, settings *CoreDNSModel) error {
+	configMaps := clientset.CoreV1().ConfigMaps(metav1.NamespaceSystem)
+	configMap, err := configMaps.Get(ctx, corednsName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get the CoreDNS ConfigMap: %w", err)
+	}
+
+	corefile, err := patchCorefile(configMap.Data[corednsCorefileKey], settings)
+	if err != nil {
+		return err
+	}
+	if corefile == configMap.Data[corednsCorefileKey] {
+		return nil
+	}
+
+	configMap.Data[corednsCorefileKey] = corefile
+	if _, err := configMaps.Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update the CoreDNS ConfigMap: %w", err)
+	}
+
+	restart := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, corednsRestartedKey, time.Now().Format(time.RFC3339))
+	if _, err := clientset.AppsV1().Deployments(metav1.NamespaceSystem).Patch(ctx, corednsName, k8stypes.StrategicMergePatchType, []byte(restart), metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to restart CoreDNS: %w", err)
+	}
+
+	return nil
+}
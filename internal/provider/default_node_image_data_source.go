@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/kind/pkg/apis/config/defaults"
+)
+
+var _ datasource.DataSource = &DefaultNodeImageDataSource{}
+
+type DefaultNodeImageDataSource struct{}
+
+func NewDefaultNodeImageDataSource() datasource.DataSource {
+	return &DefaultNodeImageDataSource{}
+}
+
+func (d *DefaultNodeImageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_node_image"
+}
+
+func (d *DefaultNodeImageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Return the node image the kind library built into the provider uses when node_image is not set.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (same as image).",
+				Computed:    true,
+			},
+			"image": schema.StringAttribute{
+				Description: "Digest-pinned default node image.",
+				Computed:    true,
+			},
+			"kubernetes_version": schema.StringAttribute{
+				Description: "Kubernetes version of the default node image, without the leading v.",
+				Computed:    true,
+			},
+			"kind_version": schema.StringAttribute{
+				Description: "Version of the kind library the provider is built with.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *DefaultNodeImageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DefaultNodeImageDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(defaults.Image)
+	data.Image = types.StringValue(defaults.Image)
+	data.KubernetesVersion = types.StringValue(defaultNodeImageVersion())
+	data.KindVersion = types.StringValue(kindLibraryVersion())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
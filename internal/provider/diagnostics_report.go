@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// operationRecord is one line of the provider's diagnostics file.
+type operationRecord struct {
+	Time            string  `json:"time"`
+	Operation       string  `json:"operation"`
+	Cluster         string  `json:"cluster"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	Success         bool    `json:"success"`
+	Error           string  `json:"error,omitempty"`
+	Warnings        int     `json:"warnings,omitempty"`
+}
+
+// recordOperation appends a JSON line describing a cluster operation to the
+// provider's diagnostics file. The outcome and error summary are derived from
+// the diagnostics collected so far. Failures to write only produce a warning.
+func (r *ClusterResource) recordOperation(clusterName, operation string, start time.Time, diagnostics *diag.Diagnostics) {
+	if r.diagnosticsFile == "" {
+		return
+	}
+
+	var errs []string
+	for _, d := range diagnostics.Errors() {
+		errs = append(errs, d.Summary()+": "+d.Detail())
+	}
+
+	line, err := json.Marshal(operationRecord{
+		Time:            start.UTC().Format(time.RFC3339),
+		Operation:       operation,
+		Cluster:         clusterName,
+		DurationSeconds: time.Since(start).Seconds(),
+		Success:         !diagnostics.HasError(),
+		Error:           strings.Join(errs, "; "),
+		Warnings:        diagnostics.WarningsCount(),
+	})
+	if err == nil {
+		err = appendToFile(r.diagnosticsFile, string(line)+"\n")
+	}
+	if err != nil {
+		diagnostics.AddWarning("Failed to write diagnostics file", err.Error())
+	}
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultKindNetwork is the container network KinD attaches node containers
+// to, unless kindNetworkEnv names another one.
+const defaultKindNetwork = "kind"
+
+// kindNetworkEnv is the environment variable KinD reads to use a different
+// node network.
+const kindNetworkEnv = "KIND_EXPERIMENTAL_DOCKER_NETWORK"
+
+var _ datasource.DataSource = &DockerNetworkDataSource{}
+
+type DockerNetworkDataSource struct {
+	runtime string
+}
+
+func NewDockerNetworkDataSource() datasource.DataSource {
+	return &DockerNetworkDataSource{}
+}
+
+func (d *DockerNetworkDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_docker_network"
+}
+
+func (d *DockerNetworkDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read the container network KinD attaches node containers to, for joining other containers such as a local registry or a proxy to it. The network is created with the first cluster.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the network.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the network. Defaults to " + kindNetworkEnv + " when set, like KinD, and to kind otherwise.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"subnet": schema.StringAttribute{
+				Description: "IPv4 subnet of the network, empty on an IPv6-only network.",
+				Computed:    true,
+			},
+			"gateway": schema.StringAttribute{
+				Description: "IPv4 gateway of the network, empty when there is none.",
+				Computed:    true,
+			},
+			"ipv6_subnet": schema.StringAttribute{
+				Description: "IPv6 subnet of the network, empty unless it is IPv6-only or dual-stack.",
+				Computed:    true,
+			},
+			"ipv6_gateway": schema.StringAttribute{
+				Description: "IPv6 gateway of the network, empty when there is none.",
+				Computed:    true,
+			},
+			"subnets": schema.ListAttribute{
+				Description: "Every subnet of the network, in the order the container runtime reports them.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *DockerNetworkDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KindProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *KindProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.runtime = providerData.Runtime
+}
+
+func (d *DockerNetworkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DockerNetworkDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	if name == "" {
+		name = kindNetworkName()
+	}
+
+	network, err := inspectNetwork(ctx, d.runtime, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read network",
+			fmt.Sprintf("%s\n\nKinD creates the %s network with the first cluster, so depend on a kind_cluster resource when none exists yet.", err, name),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(network.ID)
+	data.Name = types.StringValue(network.Name)
+	data.Subnet = types.StringValue("")
+	data.Gateway = types.StringValue("")
+	data.IPv6Subnet = types.StringValue("")
+	data.IPv6Gateway = types.StringValue("")
+
+	subnets := make([]string, 0, len(network.subnets()))
+	for _, s := range network.subnets() {
+		ip, _, err := net.ParseCIDR(s.Subnet)
+		if err != nil {
+			continue
+		}
+		subnets = append(subnets, s.Subnet)
+
+		// The first subnet of each family is the one KinD assigns nodes from.
+		if ip.To4() != nil && data.Subnet.ValueString() == "" {
+			data.Subnet = types.StringValue(s.Subnet)
+			data.Gateway = types.StringValue(s.Gateway)
+		} else if ip.To4() == nil && data.IPv6Subnet.ValueString() == "" {
+			data.IPv6Subnet = types.StringValue(s.Subnet)
+			data.IPv6Gateway = types.StringValue(s.Gateway)
+		}
+	}
+
+	subnetsValue, diags := types.ListValueFrom(ctx, types.StringType, subnets)
+	resp.Diagnostics.Append(diags...)
+	data.Subnets = subnetsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// kindNetworkName returns the network KinD attaches new node containers to.
+func kindNetworkName() string {
+	if name := os.Getenv(kindNetworkEnv); name != "" {
+		return name
+	}
+
+	return defaultKindNetwork
+}
+
+// networkSubnet is one subnet of a container network.
+type networkSubnet struct {
+	Subnet  string
+	Gateway string
+}
+
+// networkInspect holds the fields of network inspect output the provider
+// uses. Docker and nerdctl report subnets under IPAM.Config, podman under
+// subnets; JSON field matching is case-insensitive, so both decode.
+type networkInspect struct {
+	ID   string
+	Name string
+	IPAM struct {
+		Config []networkSubnet
+	}
+	Subnets []networkSubnet
+}
+
+// subnets returns the network's subnets in whichever form the runtime
+// reported them.
+func (n *networkInspect) subnets() []networkSubnet {
+	if len(n.IPAM.Config) > 0 {
+		return n.IPAM.Config
+	}
+
+	return n.Subnets
+}
+
+// inspectNetwork returns the inspect output of a container network.
+func inspectNetwork(ctx context.Context, runtime, name string) (*networkInspect, error) {
+	out, err := runContainerCommand(ctx, runtime, "network", "inspect", name)
+	if err != nil {
+		return nil, err
+	}
+
+	var inspected []networkInspect
+	if err := json.Unmarshal([]byte(out), &inspected); err != nil {
+		return nil, fmt.Errorf("failed to parse network inspect output for %s: %w", name, err)
+	}
+	if len(inspected) == 0 {
+		return nil, fmt.Errorf("network %s not found", name)
+	}
+
+	return &inspected[0], nil
+}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultDrainTimeout is the time in seconds drain_before_delete waits for
+// pods to be evicted and terminate.
+const defaultDrainTimeout = 120
+
+// drainCluster cordons every node and removes all pods that are not managed
+// by a DaemonSet or static, so workloads run their shutdown before the node
+// containers are deleted. With respectPDBs pods are evicted through the
+// Eviction API, which refuses evictions a PodDisruptionBudget does not allow;
+// those are retried until the timeout and then deleted anyway. Otherwise pods
+// are deleted right away. It returns the pods that were force-deleted or were
+// still terminating when the timeout elapsed.
+func drainCluster(ctx context.Context, clientset kubernetes.Interface, respectPDBs bool, timeout time.Duration) ([]string, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Spec.Unschedulable {
+			continue
+		}
+		node.Spec.Unschedulable = true
+		if _, err := clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+			return nil, fmt.Errorf("failed to cordon node %s: %w", node.Name, err)
+		}
+	}
+
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var pending []corev1.Pod
+	for _, pod := range pods.Items {
+		if isDaemonSetOrStaticPod(&pod) || pod.DeletionTimestamp != nil ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		pending = append(pending, pod)
+	}
+
+	ticker := time.NewTicker(defaultNodeReadyWaiter.pollInterval)
+	defer ticker.Stop()
+
+	timeoutCh := time.After(timeout)
+	timedOut := false
+
+	// Evict until every pod is on its way out or the timeout elapses.
+	var removed []corev1.Pod
+	for len(pending) > 0 && !timedOut {
+		var blocked []corev1.Pod
+		for _, pod := range pending {
+			if respectPDBs {
+				eviction := &policyv1.Eviction{
+					ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+				}
+				err = clientset.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction)
+			} else {
+				err = clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+			}
+
+			switch {
+			case err == nil:
+				removed = append(removed, pod)
+			case apierrors.IsNotFound(err):
+			case apierrors.IsTooManyRequests(err):
+				// The PodDisruptionBudget allows no disruption right now.
+				blocked = append(blocked, pod)
+			default:
+				return nil, fmt.Errorf("failed to evict pod %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+		}
+
+		pending = blocked
+		if len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeoutCh:
+			timedOut = true
+		case <-ticker.C:
+		}
+	}
+
+	var stuck []string
+	for _, pod := range pending {
+		stuck = append(stuck, fmt.Sprintf("%s/%s (eviction blocked by a PodDisruptionBudget, deleted)", pod.Namespace, pod.Name))
+		if err := clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return stuck, fmt.Errorf("failed to delete pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+	}
+
+	// Give removed pods their grace period to shut down within the timeout.
+	for len(removed) > 0 && !timedOut {
+		removed = remainingPods(ctx, clientset, removed)
+		if len(removed) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return stuck, ctx.Err()
+		case <-timeoutCh:
+			timedOut = true
+		case <-ticker.C:
+		}
+	}
+
+	for _, pod := range removed {
+		stuck = append(stuck, fmt.Sprintf("%s/%s (still terminating)", pod.Namespace, pod.Name))
+	}
+
+	return stuck, nil
+}
+
+// remainingPods returns the pods that still exist. A pod recreated under the
+// same name, as StatefulSets do, counts as gone.
+func remainingPods(ctx context.Context, clientset kubernetes.Interface, pods []corev1.Pod) []corev1.Pod {
+	var remaining []corev1.Pod
+	for _, pod := range pods {
+		current, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			continue
+		}
+		remaining = append(remaining, pod)
+	}
+
+	return remaining
+}
+
+// waitForPodsDeleted waits for pods to be deleted and returns the
+// namespace/name of those still terminating when the timeout elapses.
+func waitForPodsDeleted(ctx context.Context, clientset kubernetes.Interface, pods []corev1.Pod, timeout time.Duration) ([]string, error) {
+	ticker := time.NewTicker(defaultNodeReadyWaiter.pollInterval)
+	defer ticker.Stop()
+
+	timeoutCh := time.After(timeout)
+
+	for {
+		pods = remainingPods(ctx, clientset, pods)
+		if len(pods) == 0 {
+			return nil, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeoutCh:
+			terminating := make([]string, 0, len(pods))
+			for _, pod := range pods {
+				terminating = append(terminating, pod.Namespace+"/"+pod.Name)
+			}
+			return terminating, nil
+		case <-ticker.C:
+		}
+	}
+}
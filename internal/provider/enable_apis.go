@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runtimeConfigAliases are the --runtime-config keys that select groups of
+// APIs rather than a single group/version.
+var runtimeConfigAliases = []string{"api/all", "api/ga", "api/beta", "api/alpha"}
+
+// apiGroupVersionRegexp matches group/version[/resource] keys such as
+// flowcontrol.apiserver.k8s.io/v1beta3, and the core group's v1.
+var apiGroupVersionRegexp = regexp.MustCompile(`^(v1|[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/v[0-9]+((alpha|beta)[0-9]+)?)(/[a-z0-9]+)?$`)
+
+// parseEnableAPI splits an enable_apis entry into its --runtime-config key and
+// value. A bare group/version means the API is enabled.
+func parseEnableAPI(entry string) (string, string, error) {
+	key, value, hasValue := strings.Cut(strings.TrimSpace(entry), "=")
+	if !hasValue {
+		value = "true"
+	}
+
+	if value != "true" && value != "false" {
+		return "", "", fmt.Errorf("value %q must be true or false", value)
+	}
+
+	if !apiGroupVersionRegexp.MatchString(key) && !slices.Contains(runtimeConfigAliases, key) {
+		return "", "", fmt.Errorf("%q is not a group/version such as flowcontrol.apiserver.k8s.io/v1beta3 or one of %s", key, strings.Join(runtimeConfigAliases, ", "))
+	}
+
+	return key, value, nil
+}
+
+// runtimeConfigFromEnableAPIs compiles enable_apis into --runtime-config
+// entries. Invalid entries are skipped; validateEnableAPIs reports them.
+// Later entries win over earlier ones for the same key.
+func runtimeConfigFromEnableAPIs(entries []string) map[string]string {
+	runtimeConfig := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if key, value, err := parseEnableAPI(entry); err == nil {
+			runtimeConfig[key] = value
+		}
+	}
+
+	return runtimeConfig
+}
+
+// validateEnableAPIs rejects malformed enable_apis entries and warns about
+// entries that conflict with each other or with runtime_config, which takes
+// precedence.
+func validateEnableAPIs(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.EnableAPIs.IsNull() || data.EnableAPIs.IsUnknown() {
+		return
+	}
+
+	seen := map[string]string{}
+	var conflicts []string
+	for i, entry := range listStringValues(data.EnableAPIs) {
+		key, value, err := parseEnableAPI(entry)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("enable_apis").AtListIndex(i),
+				"Invalid API Entry",
+				fmt.Sprintf("%q is not valid: %s.", entry, err),
+			)
+			continue
+		}
+
+		if previous, ok := seen[key]; ok && previous != value {
+			conflicts = append(conflicts, fmt.Sprintf("%s is set to both %s and %s in enable_apis, the last entry wins", key, previous, value))
+		}
+		seen[key] = value
+	}
+
+	if !data.RuntimeConfig.IsNull() && !data.RuntimeConfig.IsUnknown() {
+		for key, v := range data.RuntimeConfig.Elements() {
+			s, ok := v.(types.String)
+			if !ok || s.IsNull() || s.IsUnknown() {
+				continue
+			}
+			if value, ok := seen[key]; ok && value != s.ValueString() {
+				conflicts = append(conflicts, fmt.Sprintf("%s is %s in enable_apis but %s in runtime_config, runtime_config wins", key, value, s.ValueString()))
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		diagnostics.AddAttributeWarning(
+			path.Root("enable_apis"),
+			"Conflicting API Entries",
+			strings.Join(conflicts, "\n"),
+		)
+	}
+}
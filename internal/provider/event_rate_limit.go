@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// admissionConfigFile is the API server admission configuration, staged in
+// nodeFilesDir when an admission plugin needs one.
+const admissionConfigFile = "admission-config.yaml"
+
+// eventRateLimitTypes are the limit types of the EventRateLimit admission
+// plugin: one bucket for the whole server, or one per namespace, user or
+// event source and involved object.
+var eventRateLimitTypes = []string{"Server", "Namespace", "User", "SourceAndObject"}
+
+// admissionPlugins returns the admission plugins to enable on top of the
+// kubeadm default, NodeRestriction, which setting the flag would otherwise
+// drop.
+func admissionPlugins(data *ClusterResourceModel) []string {
+	if len(data.EventRateLimits) == 0 {
+		return nil
+	}
+
+	return []string{"NodeRestriction", "EventRateLimit"}
+}
+
+// admissionConfig renders the admission configuration for the configured
+// EventRateLimit limits, or an empty string when there are none.
+func admissionConfig(data *ClusterResourceModel) string {
+	if len(data.EventRateLimits) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(`apiVersion: apiserver.config.k8s.io/v1
+kind: AdmissionConfiguration
+plugins:
+  - name: EventRateLimit
+    configuration:
+      apiVersion: eventratelimit.admission.k8s.io/v1alpha1
+      kind: Configuration
+      limits:
+`)
+	for _, l := range data.EventRateLimits {
+		fmt.Fprintf(&b, "        - type: %s\n", l.Type.ValueString())
+		fmt.Fprintf(&b, "          qps: %d\n", l.QPS.ValueInt64())
+		fmt.Fprintf(&b, "          burst: %d\n", l.Burst.ValueInt64())
+		if !l.CacheSize.IsNull() {
+			fmt.Fprintf(&b, "          cacheSize: %d\n", l.CacheSize.ValueInt64())
+		}
+	}
+
+	return b.String()
+}
+
+// validateEventRateLimits rejects more than one limit of the same type, which
+// the plugin refuses at API server start, and cache sizes on Server limits,
+// which have a single bucket.
+func validateEventRateLimits(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	seen := map[string]bool{}
+	for i, l := range data.EventRateLimits {
+		if l.Type.IsUnknown() {
+			continue
+		}
+
+		limitType := l.Type.ValueString()
+		if seen[limitType] {
+			diagnostics.AddAttributeError(
+				path.Root("event_rate_limit").AtListIndex(i).AtName("type"),
+				"Duplicate Event Rate Limit",
+				fmt.Sprintf("Only one event_rate_limit block may use type %q.", limitType),
+			)
+		}
+		seen[limitType] = true
+
+		if limitType == "Server" && !l.CacheSize.IsNull() {
+			diagnostics.AddAttributeError(
+				path.Root("event_rate_limit").AtListIndex(i).AtName("cache_size"),
+				"Invalid Cache Size",
+				"cache_size only applies to Namespace, User and SourceAndObject limits.",
+			)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"sigs.k8s.io/kind/pkg/apis/config/defaults"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/yaml"
+)
+
+// Files written to an export bundle directory.
+const (
+	bundleConfigFile     = "kind-config.yaml"
+	bundleKubeconfigFile = "kubeconfig"
+	bundleImagesFile     = "images.txt"
+)
+
+// exportClusterBundle writes the bundle for the cluster to export_bundle_path
+// when it is set. A bundle at previousPath, the path in state before an
+// update, is removed first when the path moved; Create passes "".
+func exportClusterBundle(data *ClusterResourceModel, cfg *v1alpha4.Cluster, previousPath string, diagnostics *diag.Diagnostics) {
+	bundlePath := data.ExportBundlePath.ValueString()
+	if previousPath != "" && previousPath != bundlePath {
+		if err := removeExportBundle(previousPath); err != nil {
+			diagnostics.AddWarning("Failed to remove export bundle", err.Error())
+		}
+	}
+	if bundlePath == "" {
+		return
+	}
+
+	resolved := resolveNodeImages(data, cfg)
+	images := append(clusterImages(resolved), listStringValues(data.LoadedImages)...)
+	if err := writeExportBundle(bundlePath, resolved, data.Kubeconfig.ValueString(), images); err != nil {
+		diagnostics.AddError("Failed to write export bundle", err.Error())
+	}
+}
+
+// writeExportBundle writes the rendered KinD config, the kubeconfig and the
+// list of images the cluster runs on to dir, so the cluster can be recreated
+// elsewhere with `kind create cluster --config kind-config.yaml` after pulling
+// the listed images. Host paths in extra mounts are written as-is.
+func writeExportBundle(dir string, cfg *v1alpha4.Cluster, kubeconfig string, images []string) error {
+	config, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to render kind config: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	files := []struct {
+		name    string
+		content string
+		mode    os.FileMode
+	}{
+		{bundleConfigFile, string(config), 0o644},
+		{bundleKubeconfigFile, kubeconfig, 0o600},
+		{bundleImagesFile, strings.Join(images, "\n") + "\n", 0o644},
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.name), []byte(f.content), f.mode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+// removeExportBundle deletes the files writeExportBundle created and then the
+// directory itself if nothing else was put in it.
+func removeExportBundle(dir string) error {
+	for _, name := range []string{bundleConfigFile, bundleKubeconfigFile, bundleImagesFile} {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if len(entries) > 0 {
+		// The directory holds files the provider did not write; keep them.
+		return nil
+	}
+
+	return os.Remove(dir)
+}
+
+// resolveNodeImages returns a copy of cfg with every node's image set to the
+// one KinD uses: node_image overrides all nodes, then the per-node image, then
+// KinD's default.
+func resolveNodeImages(data *ClusterResourceModel, cfg *v1alpha4.Cluster) *v1alpha4.Cluster {
+	resolved := *cfg
+	resolved.Nodes = append([]v1alpha4.Node(nil), cfg.Nodes...)
+
+	for i := range resolved.Nodes {
+		switch {
+		case !data.NodeImage.IsNull() && data.NodeImage.ValueString() != "":
+			resolved.Nodes[i].Image = data.NodeImage.ValueString()
+		case resolved.Nodes[i].Image == "":
+			resolved.Nodes[i].Image = defaults.Image
+		}
+	}
+
+	return &resolved
+}
+
+// clusterImages returns the distinct node images of a resolved config, in node
+// order.
+func clusterImages(cfg *v1alpha4.Cluster) []string {
+	var images []string
+	for _, node := range cfg.Nodes {
+		if !slices.Contains(images, node.Image) {
+			images = append(images, node.Image)
+		}
+	}
+
+	return images
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExportClusterBundleMovesPath(t *testing.T) {
+	r := &ClusterResource{}
+	dir := t.TempDir()
+	oldPath, newPath := filepath.Join(dir, "old"), filepath.Join(dir, "new")
+
+	data := &ClusterResourceModel{
+		Name:             types.StringValue("dev"),
+		Kubeconfig:       types.StringValue("apiVersion: v1\n"),
+		NodeImage:        types.StringValue("kindest/node:v1.35.0"),
+		LoadedImages:     types.ListNull(types.StringType),
+		ExportBundlePath: types.StringValue(oldPath),
+	}
+
+	var diags diag.Diagnostics
+	exportClusterBundle(data, r.buildClusterConfig(data), "", &diags)
+	if diags.HasError() {
+		t.Fatalf("create: unexpected diagnostics: %v", diags)
+	}
+
+	data.ExportBundlePath = types.StringValue(newPath)
+	exportClusterBundle(data, r.buildClusterConfig(data), oldPath, &diags)
+	if diags.HasError() {
+		t.Fatalf("update: unexpected diagnostics: %v", diags)
+	}
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("old bundle directory still exists: %v", err)
+	}
+	for _, name := range []string{bundleConfigFile, bundleKubeconfigFile, bundleImagesFile} {
+		if _, err := os.Stat(filepath.Join(newPath, name)); err != nil {
+			t.Errorf("new bundle is missing %s: %v", name, err)
+		}
+	}
+	images, err := os.ReadFile(filepath.Join(newPath, bundleImagesFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(images)); got != "kindest/node:v1.35.0" {
+		t.Errorf("images.txt = %q, want the node image", got)
+	}
+
+	data.ExportBundlePath = types.StringNull()
+	exportClusterBundle(data, r.buildClusterConfig(data), newPath, &diags)
+	if diags.HasError() {
+		t.Fatalf("unset: unexpected diagnostics: %v", diags)
+	}
+	if _, err := os.Stat(newPath); !os.IsNotExist(err) {
+		t.Errorf("bundle directory still exists after unsetting export_bundle_path: %v", err)
+	}
+}
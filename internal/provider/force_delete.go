@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// forceDeleteTimeout bounds the forced removal of node containers, which runs
+// after the delete timeout already elapsed.
+const forceDeleteTimeout = 2 * time.Minute
+
+// forceRemoveClusterContainers removes every container labelled as a node of
+// the cluster with the runtime's rm -f, bypassing KinD's delete when it hangs
+// on an unresponsive node. Anonymous volumes, such as the nodes' /var, are
+// removed with them. It returns the names of the removed containers.
+func forceRemoveClusterContainers(ctx context.Context, runtime, clusterName string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), forceDeleteTimeout)
+	defer cancel()
+
+	out, err := runContainerCommand(ctx, runtime, "ps", "-a", "--filter", fmt.Sprintf("label=%s=%s", kindClusterLabelKey, clusterName), "--format", "{{.Names}}")
+	if err != nil {
+		return nil, err
+	}
+
+	names := strings.Fields(out)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	if _, err := runContainerCommand(ctx, runtime, append([]string{"rm", "-f", "-v"}, names...)...); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// nodeContentStoreDir is the containerd content store inside the node
+// containers. Blobs are content-addressed, so the same layer pulled by two
+// nodes lands at the same path with the same bytes.
+const nodeContentStoreDir = "/var/lib/containerd/io.containerd.content.v1.content"
+
+// imageCacheContainerdPatch configures containerd for a content store shared
+// with other nodes. Metadata stays per node, so garbage collection must not
+// run on its own: a node only knows the blobs it pulled itself and would
+// delete blobs other nodes still reference. Startup and deletion-triggered
+// collections are disabled and the mutation threshold is raised out of reach.
+// Namespaces share content so CRI and ctr pulls reuse each other's blobs.
+const imageCacheContainerdPatch = `[plugins."io.containerd.metadata.v1.bolt"]
+  content_sharing_policy = "shared"
+
+[plugins."io.containerd.gc.v1.scheduler"]
+  startup_delay = "0s"
+  deletion_threshold = 0
+  mutation_threshold = 2147483647
+`
+
+// imageCacheLockStaleAge is how old an image cache lock must be before it is
+// taken to be left behind by a run that was killed. Loading large images into
+// many nodes can take minutes.
+const imageCacheLockStaleAge = 30 * time.Minute
+
+// imageCacheHostDir returns the host directory holding the content store
+// shared by every cluster with image cache passthrough enabled.
+func imageCacheHostDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, ".kube", "kind", "image-cache"), nil
+}
+
+// imageCacheLockPath returns the lock file serialising image loads into the
+// shared content store. It sits next to the store, which containerd owns.
+func imageCacheLockPath() (string, error) {
+	dir, err := imageCacheHostDir()
+	if err != nil {
+		return "", err
+	}
+
+	return dir + ".lock", nil
+}
+
+// lockImageCache takes the host-wide image cache lock, waiting for other
+// provider processes to release it until ctx is done. containerd only locks
+// content writes within its own process, so two clusters importing the same
+// layer would otherwise write the shared blob at once. The returned function
+// releases the lock.
+func lockImageCache(ctx context.Context) (func(), error) {
+	lockPath, err := imageCacheLockPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(lockPath), err)
+	}
+
+	ticker := time.NewTicker(kubeconfigLockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_, err = f.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(lockPath)
+				return nil, fmt.Errorf("failed to write %s: %w", lockPath, err)
+			}
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("failed to create %s: %w", lockPath, err)
+		}
+
+		// A lock left by a killed run would block every later load.
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > imageCacheLockStaleAge {
+			_ = os.Remove(lockPath)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s is held by another image load: %w", lockPath, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// withImageCacheLock runs load, holding the image cache lock while it does
+// when the cluster shares the content store.
+func withImageCacheLock(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics, load func()) {
+	if !data.EnableImageCachePassthrough.ValueBool() {
+		load()
+		return
+	}
+
+	unlock, err := lockImageCache(ctx)
+	if err != nil {
+		diagnostics.AddError("Failed to lock image cache", err.Error())
+		return
+	}
+	defer unlock()
+
+	load()
+}
+
+// stageImageCache creates the shared content store on the host and mounts it
+// into every node. The directory is left in place on delete since other
+// clusters may be using it.
+func stageImageCache(cfg *v1alpha4.Cluster) error {
+	dir, err := imageCacheHostDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	for i := range cfg.Nodes {
+		cfg.Nodes[i].ExtraMounts = append(cfg.Nodes[i].ExtraMounts, v1alpha4.Mount{
+			HostPath:      dir,
+			ContainerPath: nodeContentStoreDir,
+		})
+	}
+
+	return nil
+}
+
+// validateImageCachePassthrough rejects kubelet image GC thresholds together
+// with the shared content store, since kubelet image removal triggers a
+// containerd garbage collection that can delete blobs other clusters use.
+func validateImageCachePassthrough(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if !data.EnableImageCachePassthrough.ValueBool() || data.KubeletLogRotation == nil {
+		return
+	}
+
+	for _, attr := range []struct {
+		name  string
+		value bool
+	}{
+		{"image_gc_high_threshold_percent", !data.KubeletLogRotation.ImageGCHighThresholdPercent.IsNull()},
+		{"image_gc_low_threshold_percent", !data.KubeletLogRotation.ImageGCLowThresholdPercent.IsNull()},
+	} {
+		if attr.value {
+			diagnostics.AddAttributeError(
+				path.Root("kubelet_log_rotation").AtName(attr.name),
+				"Image GC Incompatible With Image Cache Passthrough",
+				"Kubelet image garbage collection cannot be enabled together with enable_image_cache_passthrough: "+
+					"removing an image runs a containerd garbage collection that deletes shared blobs other clusters still use.",
+			)
+		}
+	}
+}
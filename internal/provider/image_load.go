@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+)
+
+// loadImages loads local container images into every node of the cluster,
+// like `kind load docker-image`. The images are saved to a single archive
+// with the container runtime CLI and imported into each node's containerd.
+func (r *ClusterResource) loadImages(ctx context.Context, clusterName string, images []string, diagnostics *diag.Diagnostics) {
+	if len(images) == 0 {
+		return
+	}
+
+	nodeList, err := r.provider.ListInternalNodes(clusterName)
+	if err != nil {
+		diagnostics.AddError("Failed to load images", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	r.loadImagesIntoNodes(ctx, nodeList, images, diagnostics)
+}
+
+// loadImagesIntoNodes loads local container images into the given nodes.
+func (r *ClusterResource) loadImagesIntoNodes(ctx context.Context, nodeList []nodes.Node, images []string, diagnostics *diag.Diagnostics) {
+	if len(images) == 0 {
+		return
+	}
+
+	for _, image := range images {
+		if _, err := runContainerCommand(ctx, r.runtime, "image", "inspect", image); err != nil {
+			diagnostics.AddError(
+				"Image not found locally",
+				fmt.Sprintf("Image %q does not exist in the local %s image store. Pull or build it before loading it into the cluster.", image, r.runtime),
+			)
+		}
+	}
+	if diagnostics.HasError() {
+		return
+	}
+
+	dir, err := os.MkdirTemp("", "kind-image-load-")
+	if err != nil {
+		diagnostics.AddError("Failed to load images", err.Error())
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	archive := filepath.Join(dir, "images.tar")
+	saveArgs := append([]string{"save", "-o", archive}, images...)
+	if _, err := runContainerCommand(ctx, r.runtime, saveArgs...); err != nil {
+		diagnostics.AddError("Failed to load images", fmt.Sprintf("Saving %s failed: %s", strings.Join(images, ", "), err))
+		return
+	}
+
+	for _, node := range nodeList {
+		if err := loadImageArchive(node, archive); err != nil {
+			diagnostics.AddError(
+				"Failed to load images",
+				fmt.Sprintf("Importing %s into node %s failed: %s", strings.Join(images, ", "), node.String(), err),
+			)
+			return
+		}
+	}
+}
+
+// loadArchives loads `docker save` archives into every node of the cluster,
+// like `kind load image-archive`.
+func (r *ClusterResource) loadArchives(ctx context.Context, clusterName string, archives []string, diagnostics *diag.Diagnostics) {
+	if len(archives) == 0 {
+		return
+	}
+
+	nodeList, err := r.provider.ListInternalNodes(clusterName)
+	if err != nil {
+		diagnostics.AddError("Failed to load image archives", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	loadArchivesIntoNodes(nodeList, archives, diagnostics)
+}
+
+// loadArchivesIntoNodes loads image archives into the given nodes. Every
+// archive is checked before any is loaded, and each failing archive gets its
+// own diagnostic.
+func loadArchivesIntoNodes(nodeList []nodes.Node, archives []string, diagnostics *diag.Diagnostics) {
+	for _, archive := range archives {
+		if err := checkImageArchive(archive); err != nil {
+			diagnostics.AddError("Invalid image archive", fmt.Sprintf("Image archive %s cannot be loaded: %s", archive, err))
+		}
+	}
+	if diagnostics.HasError() {
+		return
+	}
+
+	for _, archive := range archives {
+		for _, node := range nodeList {
+			if err := loadImageArchive(node, archive); err != nil {
+				diagnostics.AddError(
+					"Failed to load image archive",
+					fmt.Sprintf("Importing %s into node %s failed: %s", archive, node.String(), err),
+				)
+				break
+			}
+		}
+	}
+}
+
+// checkImageArchive verifies that path is a readable tar archive.
+func checkImageArchive(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := tar.NewReader(f).Next(); err != nil {
+		return fmt.Errorf("not a tar archive: %w", err)
+	}
+
+	return nil
+}
+
+// validateImageArchives checks that the image_archives paths known at plan
+// time are readable tar archives. Paths computed by other resources are
+// checked again when they are loaded.
+func validateImageArchives(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.ImageArchives.IsNull() || data.ImageArchives.IsUnknown() {
+		return
+	}
+
+	for i, elem := range data.ImageArchives.Elements() {
+		archive, ok := elem.(types.String)
+		if !ok || archive.IsNull() || archive.IsUnknown() {
+			continue
+		}
+
+		if err := checkImageArchive(archive.ValueString()); err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("image_archives").AtListIndex(i),
+				"Invalid Image Archive",
+				fmt.Sprintf("Image archive %s cannot be loaded: %s", archive.ValueString(), err),
+			)
+		}
+	}
+}
+
+// loadImageArchive imports an image archive into a node's containerd.
+func loadImageArchive(node nodes.Node, archive string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return nodeutils.LoadImageArchive(node, f)
+}
+
+// addedImages returns the images or archives in planned that are not in
+// current, keeping their planned order.
+func addedImages(current, planned []string) []string {
+	var added []string
+	for _, image := range planned {
+		if !slices.Contains(current, image) && !slices.Contains(added, image) {
+			added = append(added, image)
+		}
+	}
+
+	return added
+}
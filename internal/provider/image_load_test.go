@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateImageArchives(t *testing.T) {
+	dir := t.TempDir()
+
+	archive := filepath.Join(dir, "app.tar")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := tar.NewWriter(f)
+	if err := w.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0o644}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	notArchive := filepath.Join(dir, "app.txt")
+	if err := os.WriteFile(notArchive, []byte("not a tar archive"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		archives   types.List
+		wantErrors int
+	}{
+		{"null", types.ListNull(types.StringType), 0},
+		{"unknown list", types.ListUnknown(types.StringType), 0},
+		{"archive", testStringList(types.StringValue(archive)), 0},
+		{"unknown path", testStringList(types.StringValue(archive), types.StringUnknown()), 0},
+		{"missing", testStringList(types.StringValue(filepath.Join(dir, "missing.tar"))), 1},
+		{"not an archive", testStringList(types.StringValue(notArchive)), 1},
+		{"each bad path", testStringList(types.StringValue(notArchive), types.StringValue(archive), types.StringValue(filepath.Join(dir, "missing.tar"))), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			validateImageArchives(&ClusterResourceModel{ImageArchives: tt.archives}, &diagnostics)
+
+			if got := diagnostics.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("validateImageArchives() reported %d errors, want %d: %v", got, tt.wantErrors, diagnostics)
+			}
+		})
+	}
+}
+
+func testStringList(values ...attr.Value) types.List {
+	return types.ListValueMust(types.StringType, values)
+}
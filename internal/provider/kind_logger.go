@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"sigs.k8s.io/kind/pkg/log"
+)
+
+// kindLogLevels are the values of the provider log_level attribute, from the
+// most to the least verbose.
+var kindLogLevels = []string{"trace", "debug", "info", "warn"}
+
+// defaultKindLogLevel forwards kind's user facing progress messages, the ones
+// the kind CLI prints without -v.
+const defaultKindLogLevel = "info"
+
+// kindLogger routes the kind library's log output into tflog, so TF_LOG shows
+// kind's progress through a cluster operation. kind's verbosity levels map to
+// tflog levels: V(0) to info, V(1) and V(2) to debug and V(3+) to trace.
+type kindLogger struct {
+	ctx context.Context
+	// verbosity is the highest kind verbosity level forwarded, -1 for none.
+	verbosity log.Level
+}
+
+var _ log.Logger = kindLogger{}
+
+// newKindLogger returns a logger forwarding kind messages up to the given
+// log_level. The context must carry the provider's tflog logger; the one
+// passed to Configure does, and outlives the request for logging purposes.
+func newKindLogger(ctx context.Context, level string) kindLogger {
+	verbosity := log.Level(0)
+	switch level {
+	case "trace":
+		verbosity = math.MaxInt32
+	case "debug":
+		verbosity = 2
+	case "warn":
+		verbosity = -1
+	}
+
+	return kindLogger{ctx: ctx, verbosity: verbosity}
+}
+
+func (l kindLogger) Warn(message string) {
+	tflog.Warn(l.ctx, message, map[string]interface{}{"source": "kind"})
+}
+
+func (l kindLogger) Warnf(format string, args ...interface{}) {
+	l.Warn(fmt.Sprintf(format, args...))
+}
+
+func (l kindLogger) Error(message string) {
+	tflog.Error(l.ctx, message, map[string]interface{}{"source": "kind"})
+}
+
+func (l kindLogger) Errorf(format string, args ...interface{}) {
+	l.Error(fmt.Sprintf(format, args...))
+}
+
+func (l kindLogger) V(level log.Level) log.InfoLogger {
+	return kindInfoLogger{ctx: l.ctx, level: level, enabled: level <= l.verbosity}
+}
+
+// kindInfoLogger writes kind's status messages of one verbosity level.
+type kindInfoLogger struct {
+	ctx     context.Context
+	level   log.Level
+	enabled bool
+}
+
+func (l kindInfoLogger) Info(message string) {
+	if !l.enabled {
+		return
+	}
+
+	fields := map[string]interface{}{"source": "kind", "verbosity": int(l.level)}
+	switch {
+	case l.level <= 0:
+		tflog.Info(l.ctx, message, fields)
+	case l.level <= 2:
+		tflog.Debug(l.ctx, message, fields)
+	default:
+		tflog.Trace(l.ctx, message, fields)
+	}
+}
+
+func (l kindInfoLogger) Infof(format string, args ...interface{}) {
+	if !l.enabled {
+		return
+	}
+
+	l.Info(fmt.Sprintf(format, args...))
+}
+
+func (l kindInfoLogger) Enabled() bool {
+	return l.enabled
+}
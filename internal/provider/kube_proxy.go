@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// noneProxyMode makes KinD skip installing kube-proxy, for CNIs that replace
+// it such as Cilium. The v1alpha4 API accepts it but does not name it.
+const noneProxyMode v1alpha4.ProxyMode = "none"
+
+// kubeProxyModes are the networking.kube_proxy_mode values KinD accepts.
+var kubeProxyModes = []string{
+	string(v1alpha4.IPTablesProxyMode),
+	string(v1alpha4.IPVSProxyMode),
+	string(v1alpha4.NFTablesProxyMode),
+	string(noneProxyMode),
+}
+
+// validateKubeProxyMode warns when kube-proxy is disabled while kindnet stays
+// the CNI: kindnet does not implement services, so the kubernetes service and
+// CoreDNS are unreachable and the cluster never becomes usable.
+func validateKubeProxyMode(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.Networking == nil || data.Networking.KubeProxyMode.ValueString() != string(noneProxyMode) {
+		return
+	}
+
+	if data.Networking.DisableDefaultCNI.IsUnknown() || data.Networking.DisableDefaultCNI.ValueBool() {
+		return
+	}
+
+	diagnostics.AddAttributeWarning(
+		path.Root("networking").AtName("kube_proxy_mode"),
+		"kube-proxy Disabled With the Default CNI",
+		"kube_proxy_mode none leaves services to the CNI, which kindnet does not implement. "+
+			"Set networking.disable_default_cni = true and install a kube-proxy replacement such as Cilium through cni_manifest.",
+	)
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+func TestBuildNetworkingConfigKubeProxyMode(t *testing.T) {
+	r := &ClusterResource{}
+
+	tests := []struct {
+		name    string
+		mode    types.String
+		disable types.Bool
+		want    v1alpha4.Networking
+	}{
+		{"unset", types.StringNull(), types.BoolNull(), v1alpha4.Networking{}},
+		{"empty", types.StringValue(""), types.BoolNull(), v1alpha4.Networking{}},
+		{"ipvs", types.StringValue("ipvs"), types.BoolNull(), v1alpha4.Networking{KubeProxyMode: v1alpha4.IPVSProxyMode}},
+		{"none with custom CNI", types.StringValue("none"), types.BoolValue(true), v1alpha4.Networking{KubeProxyMode: noneProxyMode, DisableDefaultCNI: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &ClusterResourceModel{
+				Name: types.StringValue("dev"),
+				Networking: &NetworkingModel{
+					KubeProxyMode:     tt.mode,
+					DisableDefaultCNI: tt.disable,
+					DNSSearch:         types.ListNull(types.StringType),
+				},
+			}
+
+			if got := r.buildNetworkingConfig(data.Networking); got != tt.want {
+				t.Errorf("buildNetworkingConfig() = %+v, want %+v", got, tt.want)
+			}
+			if got := r.buildClusterConfig(data).Networking; got.KubeProxyMode != tt.want.KubeProxyMode || got.DisableDefaultCNI != tt.want.DisableDefaultCNI {
+				t.Errorf("buildClusterConfig() networking = %+v, want kube-proxy mode %q and DisableDefaultCNI %v", got, tt.want.KubeProxyMode, tt.want.DisableDefaultCNI)
+			}
+		})
+	}
+}
+
+func TestKindnetNoKubeProxyManifest(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "kindnet-no-kube-proxy.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objects, errs := parseManifestDocuments("cni_manifest", string(content))
+	if len(errs) > 0 {
+		t.Fatalf("parseManifestDocuments() errors = %v", errs)
+	}
+	if len(objects) != 4 {
+		t.Errorf("parseManifestDocuments() returned %d objects, want 4", len(objects))
+	}
+}
+
+// testAccCheckNoKubeProxy checks with the resource's kubeconfig that KinD did
+// not install kube-proxy and that the custom CNI runs on every node.
+func testAccCheckNoKubeProxy(resourceName string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		clientset, err := newKubernetesClientset(rs.Primary.Attributes["kubeconfig"])
+		if err != nil {
+			return err
+		}
+
+		daemonSets := clientset.AppsV1().DaemonSets(metav1.NamespaceSystem)
+		if _, err := daemonSets.Get(context.Background(), "kube-proxy", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+			return fmt.Errorf("kube-proxy DaemonSet exists with kube_proxy_mode none: %v", err)
+		}
+
+		kindnet, err := daemonSets.Get(context.Background(), "kindnet", metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("custom CNI DaemonSet not found: %w", err)
+		}
+		if kindnet.Status.NumberReady != kindnet.Status.DesiredNumberScheduled {
+			return fmt.Errorf("custom CNI has %d of %d pods ready", kindnet.Status.NumberReady, kindnet.Status.DesiredNumberScheduled)
+		}
+
+		return nil
+	}
+}
+
+// TestAccClusterResourceKubeProxyNone creates a cluster without kube-proxy
+// and with a custom CNI, and checks that its nodes become Ready.
+func TestAccClusterResourceKubeProxyNone(t *testing.T) {
+	manifest, err := filepath.Abs(filepath.Join("testdata", "kindnet-no-kube-proxy.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := "kind_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "kind_cluster" "test" {
+  name         = "tf-acc-proxy-none"
+  cni_manifest = %q
+
+  networking {
+    disable_default_cni = true
+    kube_proxy_mode     = "none"
+  }
+
+  node {
+    role = "control-plane"
+  }
+
+  node {
+    role = "worker"
+  }
+}
+`, manifest),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(address, "networking.kube_proxy_mode", "none"),
+					testAccCheckReadyNodes(address, 2),
+					testAccCheckNoKubeProxy(address),
+				),
+			},
+		},
+	})
+}
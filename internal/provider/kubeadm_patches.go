@@ -0,0 +1,440 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/yaml"
+)
+
+// reservedResourceNames are the resources kubelet accepts in systemReserved
+// and kubeReserved.
+var reservedResourceNames = []string{"cpu", "memory", "ephemeral-storage", "pid"}
+
+// buildKubeletConfigPatch renders the typed kubelet settings into a
+// KubeletConfiguration merge patch. It returns an empty string when none of
+// the settings are configured, leaving KinD's kubelet defaults untouched.
+func buildKubeletConfigPatch(data *ClusterResourceModel) string {
+	kubelet := map[string]interface{}{}
+
+	if !data.FailSwapOn.IsNull() {
+		kubelet["failSwapOn"] = data.FailSwapOn.ValueBool()
+	}
+
+	if reserved := stringMapValue(data.KubeletSystemReserved); len(reserved) > 0 {
+		kubelet["systemReserved"] = reserved
+	}
+
+	if reserved := stringMapValue(data.KubeletKubeReserved); len(reserved) > 0 {
+		kubelet["kubeReserved"] = reserved
+	}
+
+	if !data.SerializeImagePulls.IsNull() {
+		kubelet["serializeImagePulls"] = data.SerializeImagePulls.ValueBool()
+	}
+
+	if !data.RegistryPullQPS.IsNull() {
+		kubelet["registryPullQPS"] = data.RegistryPullQPS.ValueInt64()
+	}
+
+	if !data.RegistryBurst.IsNull() {
+		kubelet["registryBurst"] = data.RegistryBurst.ValueInt64()
+	}
+
+	if rotation := data.KubeletLogRotation; rotation != nil {
+		if !rotation.ContainerLogMaxSize.IsNull() {
+			kubelet["containerLogMaxSize"] = rotation.ContainerLogMaxSize.ValueString()
+		}
+		if !rotation.ContainerLogMaxFiles.IsNull() {
+			kubelet["containerLogMaxFiles"] = rotation.ContainerLogMaxFiles.ValueInt64()
+		}
+		if !rotation.ImageGCHighThresholdPercent.IsNull() {
+			kubelet["imageGCHighThresholdPercent"] = rotation.ImageGCHighThresholdPercent.ValueInt64()
+		}
+		if !rotation.ImageGCLowThresholdPercent.IsNull() {
+			kubelet["imageGCLowThresholdPercent"] = rotation.ImageGCLowThresholdPercent.ValueInt64()
+		}
+	}
+
+	if shutdown := data.GracefulNodeShutdown; shutdown != nil {
+		if !shutdown.GracePeriod.IsNull() {
+			kubelet["shutdownGracePeriod"] = shutdown.GracePeriod.ValueString()
+		}
+		if !shutdown.CriticalPodsGracePeriod.IsNull() {
+			kubelet["shutdownGracePeriodCriticalPods"] = shutdown.CriticalPodsGracePeriod.ValueString()
+		}
+	}
+
+	// Kubelets request serving certificates from the cluster CA, approved by
+	// approveKubeletCerts, instead of generating self-signed ones.
+	if data.AutoApproveKubeletCerts.ValueBool() {
+		kubelet["serverTLSBootstrap"] = true
+	}
+
+	// Under IPVS NodeLocal DNSCache cannot take over the kube-dns service IP,
+	// so pods are pointed at its link-local address instead.
+	if data.InstallNodeLocalDNS.ValueBool() && kubeProxyMode(data) == string(v1alpha4.IPVSProxyMode) {
+		kubelet["clusterDNS"] = []string{nodeLocalDNSIP}
+	}
+
+	return renderConfigPatch("KubeletConfiguration", kubelet)
+}
+
+// validateGracefulNodeShutdown checks that the critical pods grace period fits
+// in the total grace period, which the kubelet splits between regular and
+// critical pods.
+func validateGracefulNodeShutdown(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	shutdown := data.GracefulNodeShutdown
+	if shutdown == nil || shutdown.CriticalPodsGracePeriod.IsNull() || shutdown.CriticalPodsGracePeriod.IsUnknown() || shutdown.GracePeriod.IsUnknown() {
+		return
+	}
+
+	if shutdown.GracePeriod.IsNull() {
+		diagnostics.AddAttributeError(
+			path.Root("graceful_node_shutdown").AtName("grace_period"),
+			"Missing Graceful Shutdown Period",
+			"grace_period must be set together with critical_pods_grace_period, the kubelet ignores the latter alone.",
+		)
+		return
+	}
+
+	// Malformed durations are reported by durationValidator.
+	critical, err := time.ParseDuration(shutdown.CriticalPodsGracePeriod.ValueString())
+	if err != nil {
+		return
+	}
+	total, err := time.ParseDuration(shutdown.GracePeriod.ValueString())
+	if err != nil {
+		return
+	}
+
+	if critical > total {
+		diagnostics.AddAttributeError(
+			path.Root("graceful_node_shutdown").AtName("critical_pods_grace_period"),
+			"Invalid Graceful Shutdown Periods",
+			fmt.Sprintf("critical_pods_grace_period (%s) must not exceed grace_period (%s), which includes it.", critical, total),
+		)
+	}
+}
+
+// validateKubeletLogRotation checks that the image GC low threshold stays
+// below the high threshold. KinD sets the high threshold to 100, so a low
+// threshold alone is compared against that.
+func validateKubeletLogRotation(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	rotation := data.KubeletLogRotation
+	if rotation == nil || rotation.ImageGCLowThresholdPercent.IsNull() || rotation.ImageGCLowThresholdPercent.IsUnknown() ||
+		rotation.ImageGCHighThresholdPercent.IsUnknown() {
+		return
+	}
+
+	high := int64(100)
+	if !rotation.ImageGCHighThresholdPercent.IsNull() {
+		high = rotation.ImageGCHighThresholdPercent.ValueInt64()
+	}
+
+	if low := rotation.ImageGCLowThresholdPercent.ValueInt64(); low >= high {
+		diagnostics.AddAttributeError(
+			path.Root("kubelet_log_rotation").AtName("image_gc_low_threshold_percent"),
+			"Invalid Image GC Thresholds",
+			fmt.Sprintf("image_gc_low_threshold_percent (%d) must be lower than image_gc_high_threshold_percent (%d).", low, high),
+		)
+	}
+}
+
+// kindKubeletArgs are the kubelet flags KinD sets in every node's
+// nodeRegistration. Overriding them breaks node registration.
+var kindKubeletArgs = []string{"node-ip", "provider-id", "node-labels"}
+
+// buildKubeletExtraArgsPatches renders a node's kubelet_extra_args into merge
+// patches for both the InitConfiguration and the JoinConfiguration, since the
+// first control plane inits and every other node joins. KinD generates
+// kubeletExtraArgs as a map, so the patches merge with the flags it sets.
+func buildKubeletExtraArgsPatches(node *NodeModel) []string {
+	args := stringMapValue(node.KubeletExtraArgs)
+	if len(args) == 0 {
+		return nil
+	}
+
+	patches := make([]string, 0, 2)
+	for _, kind := range []string{"InitConfiguration", "JoinConfiguration"} {
+		patch := renderConfigPatch(kind, map[string]interface{}{
+			"nodeRegistration": map[string]interface{}{"kubeletExtraArgs": args},
+		})
+		if patch != "" {
+			patches = append(patches, patch)
+		}
+	}
+
+	return patches
+}
+
+// validateKubeletExtraArgs checks that kubelet_extra_args keys are bare flag
+// names and leave the flags KinD manages alone.
+func validateKubeletExtraArgs(nodes []NodeModel, diagnostics *diag.Diagnostics) {
+	for i, node := range nodes {
+		if node.KubeletExtraArgs.IsNull() || node.KubeletExtraArgs.IsUnknown() {
+			continue
+		}
+
+		attrPath := path.Root("node").AtListIndex(i).AtName("kubelet_extra_args")
+		for name := range node.KubeletExtraArgs.Elements() {
+			switch {
+			case strings.HasPrefix(name, "-"):
+				diagnostics.AddAttributeError(
+					attrPath.AtMapKey(name),
+					"Invalid Kubelet Flag",
+					fmt.Sprintf("kubelet_extra_args keys are flag names without the leading dashes, e.g. %q instead of %q.", strings.TrimLeft(name, "-"), name),
+				)
+			case name == "node-labels":
+				diagnostics.AddAttributeError(
+					attrPath.AtMapKey(name),
+					"Kubelet Flag Managed by KinD",
+					"node-labels is generated by KinD from the node's labels. Set the labels attribute instead.",
+				)
+			case slices.Contains(kindKubeletArgs, name):
+				diagnostics.AddAttributeError(
+					attrPath.AtMapKey(name),
+					"Kubelet Flag Managed by KinD",
+					fmt.Sprintf("%s is set by KinD for the node to register with the cluster and cannot be overridden.", name),
+				)
+			}
+		}
+	}
+}
+
+// buildKubeProxyConfigPatch renders the typed kube-proxy settings into a
+// KubeProxyConfiguration merge patch. It returns an empty string when none of
+// the settings are configured.
+func buildKubeProxyConfigPatch(data *ClusterResourceModel) string {
+	conntrackSettings := data.KubeProxyConntrack
+	if conntrackSettings == nil {
+		return ""
+	}
+
+	conntrack := map[string]interface{}{}
+	if !conntrackSettings.MaxPerCore.IsNull() {
+		conntrack["maxPerCore"] = conntrackSettings.MaxPerCore.ValueInt64()
+	}
+	if !conntrackSettings.Min.IsNull() {
+		conntrack["min"] = conntrackSettings.Min.ValueInt64()
+	}
+	if !conntrackSettings.TCPEstablishedTimeout.IsNull() {
+		conntrack["tcpEstablishedTimeout"] = conntrackSettings.TCPEstablishedTimeout.ValueString()
+	}
+	if !conntrackSettings.TCPCloseWaitTimeout.IsNull() {
+		conntrack["tcpCloseWaitTimeout"] = conntrackSettings.TCPCloseWaitTimeout.ValueString()
+	}
+
+	if len(conntrack) == 0 {
+		return ""
+	}
+
+	return renderConfigPatch("KubeProxyConfiguration", map[string]interface{}{"conntrack": conntrack})
+}
+
+// buildClusterConfigurationPatch renders the typed control-plane settings into
+// a ClusterConfiguration merge patch. It returns an empty string when none of
+// the settings are configured.
+func buildClusterConfigurationPatch(data *ClusterResourceModel) string {
+	apiServerArgs := map[string]string{}
+	var apiServerVolumes []map[string]interface{}
+
+	if len(clusterFiles(data)) > 0 {
+		apiServerVolumes = append(apiServerVolumes, hostPathVolume("terraform-files", nodeFilesDir, true))
+	}
+
+	if auditPolicy(data) != "" {
+		apiServerArgs["audit-policy-file"] = nodeFilesDir + "/" + auditPolicyFile
+		apiServerArgs["audit-log-path"] = auditLogDir + "/audit.log"
+		apiServerVolumes = append(apiServerVolumes, hostPathVolume("audit-logs", auditLogDir, false))
+	}
+
+	if plugins := admissionPlugins(data); len(plugins) > 0 {
+		apiServerArgs["enable-admission-plugins"] = strings.Join(plugins, ",")
+		apiServerArgs["admission-control-config-file"] = nodeFilesDir + "/" + admissionConfigFile
+	}
+
+	if data.APIServerTracing != nil {
+		apiServerArgs["tracing-config-file"] = nodeFilesDir + "/" + tracingConfigFile
+	}
+
+	if data.Networking != nil && !data.Networking.ServiceNodePortRange.IsNull() && data.Networking.ServiceNodePortRange.ValueString() != "" {
+		apiServerArgs["service-node-port-range"] = data.Networking.ServiceNodePortRange.ValueString()
+	}
+
+	if sizes := int64MapValue(data.WatchCacheSizes); len(sizes) > 0 {
+		resources := make([]string, 0, len(sizes))
+		for resource := range sizes {
+			resources = append(resources, resource)
+		}
+		sort.Strings(resources)
+
+		entries := make([]string, len(resources))
+		for i, resource := range resources {
+			entries[i] = fmt.Sprintf("%s#%d", resource, sizes[resource])
+		}
+		apiServerArgs["watch-cache-sizes"] = strings.Join(entries, ",")
+	}
+
+	if !data.DefaultWatchCacheSize.IsNull() {
+		apiServerArgs["default-watch-cache-size"] = strconv.FormatInt(data.DefaultWatchCacheSize.ValueInt64(), 10)
+	}
+
+	if !data.APIServerShutdownDelayDuration.IsNull() {
+		apiServerArgs["shutdown-delay-duration"] = data.APIServerShutdownDelayDuration.ValueString()
+	}
+
+	if !data.APIServerShutdownWatchGrace.IsNull() {
+		apiServerArgs["shutdown-watch-termination-grace-period"] = data.APIServerShutdownWatchGrace.ValueString()
+	}
+
+	if !data.APIServerShutdownSendRetryAfter.IsNull() {
+		apiServerArgs["shutdown-send-retry-after"] = strconv.FormatBool(data.APIServerShutdownSendRetryAfter.ValueBool())
+	}
+
+	if !data.GoawayChance.IsNull() {
+		apiServerArgs["goaway-chance"] = strconv.FormatFloat(data.GoawayChance.ValueFloat64(), 'g', -1, 64)
+	}
+
+	// Explicit flags come last so they win over the derived ones.
+	for name, value := range stringMapValue(data.APIServerExtraArgs) {
+		apiServerArgs[name] = value
+	}
+
+	apiServer := map[string]interface{}{}
+	if len(apiServerArgs) > 0 {
+		apiServer["extraArgs"] = apiServerArgs
+	}
+	if len(apiServerVolumes) > 0 {
+		apiServer["extraVolumes"] = apiServerVolumes
+	}
+
+	controllerManagerArgs := map[string]string{}
+
+	if !data.HPASyncPeriod.IsNull() {
+		controllerManagerArgs["horizontal-pod-autoscaler-sync-period"] = data.HPASyncPeriod.ValueString()
+	}
+
+	if !data.TerminatedPodGCThreshold.IsNull() {
+		controllerManagerArgs["terminated-pod-gc-threshold"] = strconv.FormatInt(data.TerminatedPodGCThreshold.ValueInt64(), 10)
+	}
+
+	clusterConfig := map[string]interface{}{}
+	if len(apiServer) > 0 {
+		clusterConfig["apiServer"] = apiServer
+	}
+	if len(controllerManagerArgs) > 0 {
+		clusterConfig["controllerManager"] = map[string]interface{}{"extraArgs": controllerManagerArgs}
+	}
+
+	return renderConfigPatch("ClusterConfiguration", clusterConfig)
+}
+
+// hostPathVolume returns a kubeadm control-plane extra volume that mounts a
+// node directory at the same path inside the component's static pod.
+func hostPathVolume(name, dir string, readOnly bool) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      name,
+		"hostPath":  dir,
+		"mountPath": dir,
+		"readOnly":  readOnly,
+		"pathType":  "DirectoryOrCreate",
+	}
+}
+
+// renderConfigPatch marshals fields into a merge patch matching the given
+// kubeadm config kind. The apiVersion is omitted so KinD applies the patch
+// regardless of the kubeadm API version in use.
+func renderConfigPatch(kind string, fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	fields["kind"] = kind
+	out, err := yaml.Marshal(fields)
+	if err != nil {
+		// Fields only hold plain values, so marshalling cannot fail in practice.
+		return ""
+	}
+
+	return string(out)
+}
+
+// maxGoawayChance is the highest --goaway-chance the API server accepts; it
+// refuses to start beyond it.
+const maxGoawayChance = 0.02
+
+// validateGoawayChance checks goaway_chance against the range the API server
+// accepts, which is far cheaper to report at plan time than a control plane
+// that never comes up.
+func validateGoawayChance(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.GoawayChance.IsNull() || data.GoawayChance.IsUnknown() {
+		return
+	}
+
+	if chance := data.GoawayChance.ValueFloat64(); chance < 0 || chance > maxGoawayChance {
+		diagnostics.AddAttributeError(
+			path.Root("goaway_chance"),
+			"Invalid GOAWAY Chance",
+			fmt.Sprintf("goaway_chance must be between 0 and %g, got %g. The API server refuses to start with larger values.", maxGoawayChance, chance),
+		)
+	}
+}
+
+// validateAPIServerExtraArgs checks that apiserver_extra_args keys are bare
+// flag names, as kubeadm adds the dashes itself.
+func validateAPIServerExtraArgs(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.APIServerExtraArgs.IsNull() || data.APIServerExtraArgs.IsUnknown() {
+		return
+	}
+
+	for name := range data.APIServerExtraArgs.Elements() {
+		if strings.HasPrefix(name, "-") {
+			diagnostics.AddAttributeError(
+				path.Root("apiserver_extra_args").AtMapKey(name),
+				"Invalid API Server Flag",
+				fmt.Sprintf("apiserver_extra_args keys are flag names without the leading dashes, e.g. %q instead of %q.", strings.TrimLeft(name, "-"), name),
+			)
+		}
+	}
+}
+
+// validateNodePortRangeOverlap warns when an extra port mapping targets a port
+// inside the NodePort range, where the API server may allocate it to any
+// NodePort service that does not request a specific port.
+func validateNodePortRangeOverlap(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.Networking == nil || data.Networking.ServiceNodePortRange.IsNull() || data.Networking.ServiceNodePortRange.IsUnknown() {
+		return
+	}
+
+	low, high, err := parsePortRange(data.Networking.ServiceNodePortRange.ValueString())
+	if err != nil {
+		return
+	}
+
+	for i, node := range data.Nodes {
+		for j, pm := range node.ExtraPortMappings {
+			if pm.ContainerPort.IsNull() || pm.ContainerPort.IsUnknown() {
+				continue
+			}
+
+			port := pm.ContainerPort.ValueInt64()
+			if port >= low && port <= high {
+				diagnostics.AddAttributeWarning(
+					path.Root("node").AtListIndex(i).AtName("extra_port_mappings").AtListIndex(j).AtName("container_port"),
+					"Port Mapping Overlaps NodePort Range",
+					fmt.Sprintf("Container port %d is inside service_node_port_range %d-%d. Make sure the NodePort service "+
+						"that should receive this traffic requests nodePort %d explicitly, otherwise another service may be allocated it.",
+						port, low, high, port),
+				)
+			}
+		}
+	}
+}
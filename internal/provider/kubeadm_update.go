@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/yaml"
+)
+
+// nodeKubeadmConfigPath is where KinD writes the kubeadm config of a node.
+const nodeKubeadmConfigPath = "/kind/kubeadm.conf"
+
+// kubeadmControlPlaneSections are the ClusterConfiguration sections that only
+// feed the control-plane static pod manifests, which kubeadm can regenerate
+// on a running cluster.
+var kubeadmControlPlaneSections = []string{"apiServer", "controllerManager", "scheduler"}
+
+var _ planmodifier.List = kubeadmPatchesPlanModifier{}
+
+// kubeadmPatchesPlanModifier requires replacement for any change to
+// kubeadm_config_patches except appending patches that only touch the
+// control-plane sections of the ClusterConfiguration, which Update applies in
+// place. Removing or editing a patch cannot be undone on the nodes since the
+// values it replaced are not known any more.
+type kubeadmPatchesPlanModifier struct{}
+
+func (m kubeadmPatchesPlanModifier) Description(_ context.Context) string {
+	return "Requires replacement unless the change only appends ClusterConfiguration patches for apiServer, controllerManager or scheduler, other than apiServer.certSANs."
+}
+
+func (m kubeadmPatchesPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m kubeadmPatchesPlanModifier) PlanModifyList(_ context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	// Nothing to replace on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	if req.PlanValue.IsUnknown() || !canUpdateKubeadmPatchesInPlace(req.StateValue.Elements(), req.PlanValue.Elements()) {
+		resp.RequiresReplace = true
+	}
+}
+
+// canUpdateKubeadmPatchesInPlace reports whether planned only appends known
+// control-plane patches to current.
+func canUpdateKubeadmPatchesInPlace(current, planned []attr.Value) bool {
+	if len(planned) <= len(current) {
+		return false
+	}
+
+	for i := range current {
+		if !current[i].Equal(planned[i]) {
+			return false
+		}
+	}
+
+	for _, elem := range planned[len(current):] {
+		patch, ok := elem.(types.String)
+		if !ok || patch.IsUnknown() || patch.IsNull() || !isControlPlanePatch(patch.ValueString()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isControlPlanePatch reports whether a merge patch targets the
+// ClusterConfiguration and sets nothing but kubeadmControlPlaneSections.
+// apiServer.certSANs is excluded: it feeds the serving certificate, which
+// regenerating the static pod manifests does not reissue.
+func isControlPlanePatch(patch string) bool {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal([]byte(patch), &doc); err != nil || doc["kind"] != "ClusterConfiguration" {
+		return false
+	}
+
+	for key := range doc {
+		if key != "kind" && key != "apiVersion" && !slices.Contains(kubeadmControlPlaneSections, key) {
+			return false
+		}
+	}
+
+	if apiServer, ok := doc["apiServer"].(map[string]interface{}); ok {
+		if _, ok := apiServer["certSANs"]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyKubeadmPatches applies appended control-plane patches to a running
+// cluster: the kubeadm config of every control-plane node is patched the way
+// KinD patches it at creation, and kubeadm regenerates the static pod
+// manifests from it. The kubelet then restarts the changed components.
+func (r *ClusterResource) applyKubeadmPatches(ctx context.Context, data *ClusterResourceModel, patches []string, diagnostics *diag.Diagnostics) {
+	nodeList, err := r.provider.ListNodes(data.Name.ValueString())
+	if err != nil {
+		diagnostics.AddError("Failed to apply kubeadm patches", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	for _, node := range nodeList {
+		role, err := node.Role()
+		if err != nil {
+			diagnostics.AddError("Failed to apply kubeadm patches", fmt.Sprintf("Could not get the role of node %s: %s", node.String(), err))
+			return
+		}
+		if role != constants.ControlPlaneNodeRoleValue {
+			continue
+		}
+
+		config, err := readNodeFile(ctx, node, nodeKubeadmConfigPath)
+		if err != nil {
+			diagnostics.AddError("Failed to apply kubeadm patches", err.Error())
+			return
+		}
+
+		config, err = applyKubeadmMergePatches(config, patches)
+		if err != nil {
+			diagnostics.AddError("Failed to apply kubeadm patches", err.Error())
+			return
+		}
+
+		cmd := node.CommandContext(ctx, "sh", "-c", fmt.Sprintf("cat > %q", nodeKubeadmConfigPath))
+		cmd.SetStdin(strings.NewReader(config))
+		if err := cmd.Run(); err != nil {
+			diagnostics.AddError("Failed to apply kubeadm patches", fmt.Sprintf("Writing the kubeadm config on node %s failed: %s", node.String(), err))
+			return
+		}
+
+		var output strings.Builder
+		cmd = node.CommandContext(ctx, "kubeadm", "init", "phase", "control-plane", "all", "--config", nodeKubeadmConfigPath)
+		cmd.SetStdout(&output)
+		cmd.SetStderr(&output)
+		if err := cmd.Run(); err != nil {
+			diagnostics.AddError(
+				"Failed to apply kubeadm patches",
+				fmt.Sprintf("Regenerating the control plane on node %s failed: %s: %s", node.String(), err, lastLines(output.String(), 20)),
+			)
+			return
+		}
+	}
+
+	timeout := time.Duration(data.WaitForReady.ValueInt64()) * time.Second
+	if err := waitForAPIServerHealthy(ctx, clientKubeconfig(data), timeout); err != nil {
+		diagnostics.AddError("API server not healthy after applying kubeadm patches", err.Error())
+	}
+}
+
+// applyKubeadmMergePatches applies merge patches to a multi-document kubeadm
+// config with KinD's matching rules: a patch applies to the documents of its
+// kind, and of its apiVersion when it sets one.
+func applyKubeadmMergePatches(config string, patches []string) (string, error) {
+	docs := strings.Split(config, "\n---\n")
+
+	for _, patch := range patches {
+		var match struct {
+			Kind       string `json:"kind"`
+			APIVersion string `json:"apiVersion"`
+		}
+		if err := yaml.Unmarshal([]byte(patch), &match); err != nil {
+			return "", fmt.Errorf("invalid merge patch: %w", err)
+		}
+
+		patchJSON, err := yaml.YAMLToJSON([]byte(patch))
+		if err != nil {
+			return "", fmt.Errorf("invalid merge patch: %w", err)
+		}
+
+		for i, doc := range docs {
+			var meta struct {
+				Kind       string `json:"kind"`
+				APIVersion string `json:"apiVersion"`
+			}
+			if err := yaml.Unmarshal([]byte(doc), &meta); err != nil {
+				return "", fmt.Errorf("failed to parse kubeadm config: %w", err)
+			}
+			if meta.Kind != match.Kind || (match.APIVersion != "" && meta.APIVersion != match.APIVersion) {
+				continue
+			}
+
+			docJSON, err := yaml.YAMLToJSON([]byte(doc))
+			if err != nil {
+				return "", fmt.Errorf("failed to parse kubeadm config: %w", err)
+			}
+			patched, err := jsonpatch.MergePatch(docJSON, patchJSON)
+			if err != nil {
+				return "", fmt.Errorf("failed to apply merge patch to %s: %w", meta.Kind, err)
+			}
+			out, err := yaml.JSONToYAML(patched)
+			if err != nil {
+				return "", fmt.Errorf("failed to render kubeadm config: %w", err)
+			}
+			docs[i] = string(out)
+		}
+	}
+
+	return strings.Join(docs, "\n---\n"), nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+var _ datasource.DataSource = &KubeconfigDataSource{}
+
+type KubeconfigDataSource struct {
+	provider *cluster.Provider
+}
+
+func NewKubeconfigDataSource() datasource.DataSource {
+	return &KubeconfigDataSource{}
+}
+
+func (d *KubeconfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubeconfig"
+}
+
+func (d *KubeconfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read the kubeconfig of an existing KinD cluster, optionally with the API server address inside the cluster's container network.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Cluster identifier (same as name).",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the existing cluster.",
+				Required:    true,
+			},
+			"internal": schema.BoolAttribute{
+				Description: "Return the kubeconfig for the cluster's container network, with the control plane's internal address as server, for containers attached to that network. Default is false, the host-reachable address.",
+				Optional:    true,
+			},
+			"kubeconfig": schema.StringAttribute{
+				Description: "Kubeconfig content for the cluster.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (d *KubeconfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KindProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *KindProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.provider = providerData.Provider
+}
+
+func (d *KubeconfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data KubeconfigDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterName := data.Name.ValueString()
+
+	clusters, err := d.provider.List()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list clusters", err.Error())
+		return
+	}
+
+	if !slices.Contains(clusters, clusterName) {
+		resp.Diagnostics.AddError("Cluster not found", fmt.Sprintf("KinD cluster %q does not exist.", clusterName))
+		return
+	}
+
+	kubeconfig, err := d.provider.KubeConfig(clusterName, data.Internal.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get kubeconfig", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(clusterName)
+	data.Kubeconfig = types.StringValue(kubeconfig)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,358 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// kubeconfigLockRetries bounds how often a failed kubeconfig update is
+// retried.
+const kubeconfigLockRetries = 5
+
+// kubeconfigLockTimeout bounds how long a kubeconfig update waits for another
+// process, such as a parallel cluster create, to release the file's lock.
+const kubeconfigLockTimeout = 30 * time.Second
+
+// kubeconfigLockPollInterval is how often a held lock is checked again.
+const kubeconfigLockPollInterval = 500 * time.Millisecond
+
+// staleLockAge is how old a kubeconfig lock must be before it is treated as
+// left behind by an interrupted run. Locks are only held for a single write.
+const staleLockAge = 60 * time.Second
+
+// kindContextName is the context, cluster and user name KinD uses for a
+// cluster in kubeconfig files.
+func kindContextName(clusterName string) string {
+	return "kind-" + clusterName
+}
+
+// kubeconfigContextName is the context, cluster and user name of the cluster
+// in the kubeconfig the provider returns and writes: kubeconfig_context_name
+// when set, KinD's name otherwise.
+func kubeconfigContextName(data *ClusterResourceModel) string {
+	if name := data.KubeconfigContextName.ValueString(); name != "" {
+		return name
+	}
+
+	return kindContextName(data.Name.ValueString())
+}
+
+// renameKubeconfigContext renames the context, cluster and user KinD generated
+// for a cluster in kubeconfig to name, keeping the references between them and
+// the current context. The credentials are left untouched.
+func renameKubeconfigContext(kubeconfig, clusterName, name string) (string, error) {
+	from := kindContextName(clusterName)
+	if name == from {
+		return kubeconfig, nil
+	}
+
+	config, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	if cluster, ok := config.Clusters[from]; ok {
+		delete(config.Clusters, from)
+		config.Clusters[name] = cluster
+	}
+	if user, ok := config.AuthInfos[from]; ok {
+		delete(config.AuthInfos, from)
+		config.AuthInfos[name] = user
+	}
+	if kubeContext, ok := config.Contexts[from]; ok {
+		delete(config.Contexts, from)
+		if kubeContext.Cluster == from {
+			kubeContext.Cluster = name
+		}
+		if kubeContext.AuthInfo == from {
+			kubeContext.AuthInfo = name
+		}
+		config.Contexts[name] = kubeContext
+	}
+	if config.CurrentContext == from {
+		config.CurrentContext = name
+	}
+
+	out, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize kubeconfig: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// validateKubeconfigContextName rejects a blank kubeconfig_context_name.
+func validateKubeconfigContextName(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.KubeconfigContextName.IsNull() || data.KubeconfigContextName.IsUnknown() {
+		return
+	}
+
+	if strings.TrimSpace(data.KubeconfigContextName.ValueString()) == "" {
+		diagnostics.AddAttributeError(
+			path.Root("kubeconfig_context_name"),
+			"Invalid Context Name",
+			"kubeconfig_context_name must not be blank. Leave it unset to keep KinD's kind-<name>.",
+		)
+	}
+}
+
+// defaultKubeconfigPath returns the kubeconfig file kubectl uses by default,
+// honouring KUBECONFIG.
+func defaultKubeconfigPath() string {
+	return clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+}
+
+// mergeKubeconfig merges the cluster's context into the default kubeconfig
+// under contextName. KinD exports its own names; other names are merged by the
+// provider, under the same lock file, and made the current context as KinD
+// does.
+func (r *ClusterResource) mergeKubeconfig(ctx context.Context, clusterName, contextName string) error {
+	if contextName == kindContextName(clusterName) {
+		return retryKubeconfigUpdate(ctx, func() error {
+			return r.provider.ExportKubeConfig(clusterName, "", false)
+		})
+	}
+
+	kubeconfig, err := r.provider.KubeConfig(clusterName, false)
+	if err != nil {
+		return err
+	}
+	kubeconfig, err = renameKubeconfigContext(kubeconfig, clusterName, contextName)
+	if err != nil {
+		return err
+	}
+	renamed, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return err
+	}
+
+	kubeconfigPath := defaultKubeconfigPath()
+	return retryKubeconfigUpdate(ctx, func() error {
+		unlock, err := lockKubeconfig(kubeconfigPath)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		config, err := clientcmd.LoadFromFile(kubeconfigPath)
+		if os.IsNotExist(err) {
+			config, err = clientcmdapi.NewConfig(), nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for name, cluster := range renamed.Clusters {
+			config.Clusters[name] = cluster
+		}
+		for name, user := range renamed.AuthInfos {
+			config.AuthInfos[name] = user
+		}
+		for name, kubeContext := range renamed.Contexts {
+			config.Contexts[name] = kubeContext
+		}
+		config.CurrentContext = contextName
+
+		return clientcmd.WriteToFile(*config, kubeconfigPath)
+	})
+}
+
+// removeKubeconfigEntries removes the context, cluster and user named name
+// from the kubeconfig at path, taking the same lock file KinD uses so
+// concurrent creates and deletes do not overwrite each other's changes.
+func removeKubeconfigEntries(ctx context.Context, path, name string) error {
+	return retryKubeconfigUpdate(ctx, func() error {
+		unlock, err := lockKubeconfig(path)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		config, err := clientcmd.LoadFromFile(path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		delete(config.Contexts, name)
+		delete(config.Clusters, name)
+		delete(config.AuthInfos, name)
+		if config.CurrentContext == name {
+			config.CurrentContext = ""
+		}
+
+		return clientcmd.WriteToFile(*config, path)
+	})
+}
+
+// lockKubeconfig creates the lock file KinD uses for the kubeconfig at path
+// and returns a function releasing it. It fails if the lock is already held.
+// Locks left behind by interrupted runs are removed by cleanupStaleLockFile.
+//
+// KinD and client-go lock with the same exclusive create, so an advisory
+// lock on the file would not keep them out.
+func lockKubeconfig(path string) (func(), error) {
+	lockPath := path + ".lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o750); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	f.Close()
+
+	return func() { os.Remove(lockPath) }, nil
+}
+
+// kubeconfigLockPath returns the lock file an error reports as already
+// existing, or "" when err is not about a held kubeconfig lock. It covers the
+// provider's own locking and KinD's, which wraps the same os error.
+func kubeconfigLockPath(err error) string {
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) || !errors.Is(pathErr.Err, fs.ErrExist) || !strings.HasSuffix(pathErr.Path, ".lock") {
+		return ""
+	}
+
+	return pathErr.Path
+}
+
+// kubeconfigErrorDetail returns the diagnostic detail for a failed kubeconfig
+// update, explaining what to do when another process held the file's lock.
+func kubeconfigErrorDetail(err error) string {
+	lockPath := kubeconfigLockPath(err)
+	if lockPath == "" {
+		return err.Error()
+	}
+
+	return fmt.Sprintf("%s\n\nThe kubeconfig lock file %s is held by another process. "+
+		"Another terraform run, or a kind or kubectl command, may be updating the same kubeconfig; wait for it to finish and apply again. "+
+		"If none is running, the lock was left by an interrupted run: remove the file, or apply again after %s when the provider removes it.",
+		err, lockPath, staleLockAge)
+}
+
+// retryKubeconfigUpdate runs update until it succeeds. A held lock is waited
+// for up to kubeconfigLockTimeout; other failures are retried with a backoff.
+func retryKubeconfigUpdate(ctx context.Context, update func() error) error {
+	deadline := time.Now().Add(kubeconfigLockTimeout)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = update(); err == nil {
+			return nil
+		}
+
+		delay := time.Duration(attempt) * time.Second
+		if kubeconfigLockPath(err) != "" {
+			if time.Now().After(deadline) {
+				return err
+			}
+			delay = kubeconfigLockPollInterval
+		} else if attempt >= kubeconfigLockRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// cleanupStaleLockFile removes the default kubeconfig's lock file when it is
+// older than staleLockAge, as left by an interrupted run. A fresh lock is
+// left alone and waited for by retryKubeconfigUpdate.
+func cleanupStaleLockFile(ctx context.Context) {
+	lockPath := defaultKubeconfigPath() + ".lock"
+	info, err := os.Stat(lockPath)
+	if err != nil {
+		return
+	}
+
+	if age := time.Since(info.ModTime()); age > staleLockAge {
+		if err := os.Remove(lockPath); err == nil {
+			tflog.Info(ctx, "Removed stale kubeconfig lock file", map[string]interface{}{"path": lockPath, "age": age.String()})
+		}
+	}
+}
+
+// expandPath resolves a leading ~ to the home directory and makes relative
+// paths absolute.
+func expandPath(p string) (string, error) {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		p = filepath.Join(homeDir, strings.TrimPrefix(p, "~"))
+	}
+
+	return filepath.Abs(p)
+}
+
+// ownedKubeconfigFile reports whether the file at path may be written or
+// removed for a cluster: it does not exist, or it is a kubeconfig holding
+// nothing but the cluster's context, as the provider writes it. Anything else,
+// such as a shared ~/.kube/config, belongs to the user.
+func ownedKubeconfigFile(path, contextName string) bool {
+	config, err := clientcmd.LoadFromFile(path)
+	if os.IsNotExist(err) {
+		return true
+	}
+	if err != nil {
+		return false
+	}
+
+	_, ok := config.Contexts[contextName]
+	return ok && len(config.Contexts) == 1
+}
+
+// removeOwnedKubeconfigFile removes the kubeconfig at path if the provider
+// wrote it for the cluster. Files that hold other entries are left alone.
+func removeOwnedKubeconfigFile(path, contextName string) error {
+	if !ownedKubeconfigFile(path, contextName) {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// writeKubeconfigFile writes a kubeconfig to path, creating its parent
+// directories. The file is only readable by the owner since it holds
+// credentials. A file that already has the content is left as is.
+func writeKubeconfigFile(path, kubeconfig string) error {
+	if current, err := os.ReadFile(path); err == nil && string(current) == kubeconfig {
+		return os.Chmod(path, 0o600)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create the directory of %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, []byte(kubeconfig), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	// WriteFile keeps the mode of an existing file.
+	return os.Chmod(path, 0o600)
+}
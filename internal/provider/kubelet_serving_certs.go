@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// nodeUserPrefix and nodesGroup identify kubelet credentials: each kubelet
+// authenticates as system:node:<node name> in the system:nodes group.
+const (
+	nodeUserPrefix = "system:node:"
+	nodesGroup     = "system:nodes"
+)
+
+// approveKubeletCerts approves the serving certificate requests of every
+// node kubelet, which auto_approve_kubelet_certs makes request a certificate
+// signed by the cluster CA instead of using a self-signed one. The
+// controller manager signs approved requests, but leaves approving them to
+// the cluster administrator.
+func (r *ClusterResource) approveKubeletCerts(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	nodeList, err := r.provider.ListInternalNodes(data.Name.ValueString())
+	if err != nil {
+		diagnostics.AddError("Failed to approve kubelet certificates", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	nodeNames := make([]string, len(nodeList))
+	for i, node := range nodeList {
+		nodeNames[i] = node.String()
+	}
+
+	clientset, err := newKubernetesClientset(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddError("Failed to approve kubelet certificates", err.Error())
+		return
+	}
+
+	timeout := time.Duration(data.WaitForReady.ValueInt64()) * time.Second
+	if err := approveKubeletServingCSRs(ctx, clientset, nodeNames, timeout); err != nil {
+		diagnostics.AddError("Failed to approve kubelet certificates", err.Error())
+	}
+}
+
+// approveKubeletServingCSRs polls the certificate signing requests until every
+// named node has an approved kubelet serving request, approving pending ones
+// on the way. Only requests made with the node's own kubelet credentials are
+// approved.
+func approveKubeletServingCSRs(ctx context.Context, clientset kubernetes.Interface, nodeNames []string, timeout time.Duration) error {
+	ticker := time.NewTicker(defaultNodeReadyWaiter.pollInterval)
+	defer ticker.Stop()
+
+	timeoutCh := time.After(timeout)
+	var status string
+
+	for {
+		pending := map[string]bool{}
+		for _, name := range nodeNames {
+			pending[name] = true
+		}
+
+		csrs, err := clientset.CertificatesV1().CertificateSigningRequests().List(ctx, metav1.ListOptions{})
+		if err == nil {
+			var approveErr error
+			for i := range csrs.Items {
+				csr := &csrs.Items[i]
+				node, ok := kubeletServingRequestNode(csr)
+				if !ok || !pending[node] || isCSRDenied(csr) {
+					continue
+				}
+
+				if !isCSRApproved(csr) {
+					csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+						Type:           certificatesv1.CertificateApproved,
+						Status:         corev1.ConditionTrue,
+						Reason:         "AutoApproved",
+						Message:        "Approved by terraform-provider-kind (auto_approve_kubelet_certs)",
+						LastUpdateTime: metav1.Now(),
+					})
+					if _, err := clientset.CertificatesV1().CertificateSigningRequests().UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+						approveErr = fmt.Errorf("approving %s failed: %w", csr.Name, err)
+						continue
+					}
+				}
+				delete(pending, node)
+			}
+
+			if len(pending) == 0 {
+				return nil
+			}
+
+			waiting := make([]string, 0, len(pending))
+			for name := range pending {
+				waiting = append(waiting, name)
+			}
+			sort.Strings(waiting)
+			status = "no serving certificate request from " + strings.Join(waiting, ", ")
+			if approveErr != nil {
+				status = approveErr.Error()
+			}
+		} else {
+			status = err.Error()
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("kubelet serving certificates not approved: %s: %w", status, ctx.Err())
+		case <-timeoutCh:
+			return fmt.Errorf("kubelet serving certificates not approved after %s: %s", timeout, status)
+		case <-ticker.C:
+		}
+	}
+}
+
+// kubeletServingRequestNode returns the node a kubelet serving certificate
+// request was made by, and false for any other request.
+func kubeletServingRequestNode(csr *certificatesv1.CertificateSigningRequest) (string, bool) {
+	if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName || !slices.Contains(csr.Spec.Groups, nodesGroup) {
+		return "", false
+	}
+
+	return strings.CutPrefix(csr.Spec.Username, nodeUserPrefix)
+}
+
+// isCSRApproved reports whether a certificate signing request was approved.
+func isCSRApproved(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, c := range csr.Status.Conditions {
+		if c.Type == certificatesv1.CertificateApproved && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isCSRDenied reports whether a certificate signing request was denied or
+// failed, which approving cannot undo.
+func isCSRDenied(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, c := range csr.Status.Conditions {
+		if (c.Type == certificatesv1.CertificateDenied || c.Type == certificatesv1.CertificateFailed) && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,377 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+// kubernetesRequestTimeout bounds individual API requests so that an
+// unreachable cluster does not block Terraform operations indefinitely.
+const kubernetesRequestTimeout = 30 * time.Second
+
+// clientsetCache shares clientsets between operations in the same provider
+// process. Entries are keyed by a hash of the kubeconfig content, so a
+// recreated cluster with new credentials gets a fresh client, and are evicted
+// when the cluster is deleted so the cache does not grow with every cluster
+// the process has seen.
+type clientsetCache struct {
+	mu         sync.Mutex
+	clientsets map[[sha256.Size]byte]*kubernetes.Clientset
+}
+
+var sharedClientsets = &clientsetCache{
+	clientsets: make(map[[sha256.Size]byte]*kubernetes.Clientset),
+}
+
+// get returns the cached clientset for the kubeconfig, building it on first use.
+func (c *clientsetCache) get(kubeconfigContent string) (*kubernetes.Clientset, error) {
+	key := sha256.Sum256([]byte(kubeconfigContent))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if clientset, ok := c.clientsets[key]; ok {
+		return clientset, nil
+	}
+
+	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfigContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubeconfig: %w", err)
+	}
+	config.Timeout = kubernetesRequestTimeout
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	c.clientsets[key] = clientset
+	return clientset, nil
+}
+
+// evict drops the cached clientset for the kubeconfig, if any.
+func (c *clientsetCache) evict(kubeconfigContent string) {
+	key := sha256.Sum256([]byte(kubeconfigContent))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.clientsets, key)
+}
+
+// newKubernetesClientset returns a Kubernetes clientset for kubeconfig content.
+// Clientsets are shared across operations through sharedClientsets.
+func newKubernetesClientset(kubeconfigContent string) (*kubernetes.Clientset, error) {
+	return sharedClientsets.get(kubeconfigContent)
+}
+
+// nodeReadyWaiter polls a cluster until all of its nodes report Ready. A single
+// waiter can serve many clusters concurrently; each call to Wait carries its
+// own context and timeout.
+type nodeReadyWaiter struct {
+	clients      *clientsetCache
+	pollInterval time.Duration
+}
+
+var defaultNodeReadyWaiter = &nodeReadyWaiter{
+	clients:      sharedClientsets,
+	pollInterval: 5 * time.Second,
+}
+
+// Wait blocks until at least expectedNodes nodes have registered and every
+// node of the cluster is Ready, the timeout elapses, or the context is
+// cancelled. Requiring the count keeps Wait from succeeding while only the
+// control plane has registered and the workers are still joining.
+func (w *nodeReadyWaiter) Wait(ctx context.Context, kubeconfigContent string, expectedNodes int, timeout time.Duration) error {
+	clientset, err := w.clients.get(kubeconfigContent)
+	if err != nil {
+		return err
+	}
+
+	// Poll until all nodes are ready or timeout
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	timeoutCh := time.After(timeout)
+
+	var notReadyNodes []string
+	registered := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeoutCh:
+			if registered < expectedNodes {
+				return fmt.Errorf("timeout waiting for nodes to be ready after %v, %d/%d nodes registered", timeout, registered, expectedNodes)
+			}
+			if len(notReadyNodes) > 0 {
+				return fmt.Errorf("timeout waiting for nodes to be ready after %v, not ready: %s", timeout, strings.Join(notReadyNodes, ", "))
+			}
+			return fmt.Errorf("timeout waiting for nodes to be ready after %v", timeout)
+		case <-ticker.C:
+			nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				// Cluster might not be fully ready yet, continue polling
+				continue
+			}
+
+			registered = len(nodes.Items)
+			if registered == 0 || registered < expectedNodes {
+				// Not every node has joined yet, continue polling
+				continue
+			}
+
+			notReadyNodes = notReadyNodes[:0]
+			for _, node := range nodes.Items {
+				if !isNodeReady(&node) {
+					notReadyNodes = append(notReadyNodes, node.Name)
+				}
+			}
+
+			if len(notReadyNodes) == 0 {
+				return nil
+			}
+			sort.Strings(notReadyNodes)
+			// Continue polling - some nodes are not ready yet
+		}
+	}
+}
+
+// waitForAllNodesReady waits for expectedNodes nodes to register and for all
+// nodes in the cluster to be in Ready state. It uses the kubeconfig to connect
+// to the cluster and polls node status.
+func waitForAllNodesReady(ctx context.Context, kubeconfigContent string, expectedNodes int, timeout time.Duration) error {
+	return defaultNodeReadyWaiter.Wait(ctx, kubeconfigContent, expectedNodes, timeout)
+}
+
+// expectedNodeCount returns the number of Kubernetes nodes the configuration
+// creates. The external load balancer KinD adds for several control planes
+// does not register as a node and is not counted.
+func expectedNodeCount(data *ClusterResourceModel) int {
+	if len(data.Nodes) == 0 {
+		return len(defaultNodes())
+	}
+
+	return len(data.Nodes)
+}
+
+// apiHealthEndpoints are the API server endpoints polled by
+// waitForAPIServerHealthy. Both must answer ok.
+var apiHealthEndpoints = []string{"/healthz", "/readyz"}
+
+// waitForAPIServerHealthy polls the API server health endpoints until they all
+// return ok, without looking at node readiness. It suits clusters whose nodes
+// stay NotReady until a CNI is installed.
+func waitForAPIServerHealthy(ctx context.Context, kubeconfigContent string, timeout time.Duration) error {
+	clientset, err := sharedClientsets.get(kubeconfigContent)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(defaultNodeReadyWaiter.pollInterval)
+	defer ticker.Stop()
+
+	timeoutCh := time.After(timeout)
+
+	lastStatus := "no response"
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeoutCh:
+			return fmt.Errorf("timeout waiting for the API server to be healthy after %v, last response: %s", timeout, lastStatus)
+		case <-ticker.C:
+			healthy := true
+			for _, endpoint := range apiHealthEndpoints {
+				body, err := clientset.Discovery().RESTClient().Get().AbsPath(endpoint).DoRaw(ctx)
+				if err != nil || strings.TrimSpace(string(body)) != "ok" {
+					healthy = false
+					if len(body) > 0 {
+						lastStatus = fmt.Sprintf("%s: %s", endpoint, strings.TrimSpace(string(body)))
+					} else if err != nil {
+						lastStatus = fmt.Sprintf("%s: %s", endpoint, err)
+					}
+					break
+				}
+			}
+
+			if healthy {
+				return nil
+			}
+		}
+	}
+}
+
+// isNodeReady reports whether the node has a true Ready condition.
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitForCluster runs the readiness wait selected in the configuration: API
+// server health when wait_for_api_only is set, otherwise node readiness when
+// wait_for_nodes_ready is set, followed by the wait_for_deployments rollouts.
+// All use the wait_for_ready timeout.
+func waitForCluster(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	timeout := time.Duration(data.WaitForReady.ValueInt64()) * time.Second
+
+	if data.WaitForAPIOnly.ValueBool() {
+		if err := waitForAPIServerHealthy(ctx, clientKubeconfig(data), timeout); err != nil {
+			diagnostics.AddError("Failed waiting for the API server to be healthy", err.Error())
+			return
+		}
+	} else if !data.WaitForNodesReady.IsNull() && data.WaitForNodesReady.ValueBool() {
+		if err := waitForAllNodesReady(ctx, clientKubeconfig(data), expectedNodeCount(data), timeout); err != nil {
+			diagnostics.AddError("Failed waiting for nodes to be ready", err.Error())
+			return
+		}
+	}
+
+	if deployments := listStringValues(data.WaitForDeployments); len(deployments) > 0 {
+		if err := waitForDeploymentsRolledOut(ctx, clientKubeconfig(data), deployments, timeout); err != nil {
+			diagnostics.AddError("Failed waiting for deployments to be ready", err.Error())
+		}
+	}
+}
+
+// waitForDeploymentsRolledOut polls deployments, given as namespace/name,
+// until each has observed its latest spec and has all of its replicas
+// available. On timeout the error lists every deployment still not ready
+// with its last observed status.
+func waitForDeploymentsRolledOut(ctx context.Context, kubeconfigContent string, deployments []string, timeout time.Duration) error {
+	clientset, err := sharedClientsets.get(kubeconfigContent)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(defaultNodeReadyWaiter.pollInterval)
+	defer ticker.Stop()
+
+	timeoutCh := time.After(timeout)
+
+	pending := slices.Clone(deployments)
+	statuses := map[string]string{}
+	for {
+		var notReady []string
+		for _, deployment := range pending {
+			namespace, name, _ := strings.Cut(deployment, "/")
+			d, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			switch {
+			case err != nil:
+				statuses[deployment] = err.Error()
+			case d.Status.ObservedGeneration < d.Generation:
+				statuses[deployment] = "the latest spec is not observed yet"
+			case d.Spec.Replicas != nil && d.Status.AvailableReplicas == *d.Spec.Replicas && d.Status.UpdatedReplicas == *d.Spec.Replicas:
+				continue
+			default:
+				statuses[deployment] = deploymentStatus(d)
+			}
+			notReady = append(notReady, deployment)
+		}
+
+		pending = notReady
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("deployments not ready:\n%s: %w", describeDeployments(pending, statuses), ctx.Err())
+		case <-timeoutCh:
+			return fmt.Errorf("deployments not ready after %s:\n%s", timeout, describeDeployments(pending, statuses))
+		case <-ticker.C:
+		}
+	}
+}
+
+// describeDeployments renders one line per deployment with its status.
+func describeDeployments(deployments []string, statuses map[string]string) string {
+	lines := make([]string, len(deployments))
+	for i, deployment := range deployments {
+		lines[i] = fmt.Sprintf("  %s: %s", deployment, statuses[deployment])
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// clientKubeconfig returns the kubeconfig the provider uses to reach the
+// cluster API: wait_kubeconfig_override when set, the generated one otherwise.
+func clientKubeconfig(data *ClusterResourceModel) string {
+	if !data.WaitKubeconfigOverride.IsNull() && data.WaitKubeconfigOverride.ValueString() != "" {
+		return data.WaitKubeconfigOverride.ValueString()
+	}
+
+	return data.Kubeconfig.ValueString()
+}
+
+// kubeconfigCredentials are the connection details of the first cluster and
+// user in a KinD kubeconfig. Certificate fields hold base64 data as found in
+// the kubeconfig; missing fields are empty.
+type kubeconfigCredentials struct {
+	Endpoint             string
+	ClusterCaCertificate string
+	ClientCertificate    string
+	ClientKey            string
+}
+
+// parseKubeconfigCredentials extracts the connection details from kubeconfig
+// content.
+func parseKubeconfigCredentials(kubeconfig string) (kubeconfigCredentials, error) {
+	var creds kubeconfigCredentials
+
+	var kubeconfigData map[string]interface{}
+	if err := yaml.Unmarshal([]byte(kubeconfig), &kubeconfigData); err != nil {
+		return creds, err
+	}
+
+	if clusters, ok := kubeconfigData["clusters"].([]interface{}); ok && len(clusters) > 0 {
+		if clusterData, ok := clusters[0].(map[string]interface{}); ok {
+			if clusterInfo, ok := clusterData["cluster"].(map[string]interface{}); ok {
+				creds.Endpoint, _ = clusterInfo["server"].(string)
+				creds.ClusterCaCertificate, _ = clusterInfo["certificate-authority-data"].(string)
+			}
+		}
+	}
+
+	if users, ok := kubeconfigData["users"].([]interface{}); ok && len(users) > 0 {
+		if userData, ok := users[0].(map[string]interface{}); ok {
+			if userInfo, ok := userData["user"].(map[string]interface{}); ok {
+				creds.ClientCertificate, _ = userInfo["client-certificate-data"].(string)
+				creds.ClientKey, _ = userInfo["client-key-data"].(string)
+			}
+		}
+	}
+
+	return creds, nil
+}
+
+// kindKubeconfigPath returns the path the provider reports for a cluster's
+// kubeconfig.
+func kindKubeconfigPath(clusterName string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, ".kube", "kind", "kind-"+clusterName), nil
+}
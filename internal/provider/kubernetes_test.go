@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// testKubeconfig returns kubeconfig content for a cluster named name. The
+// server is never contacted.
+func testKubeconfig(t testing.TB, name string) string {
+	t.Helper()
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters["kind-"+name] = &clientcmdapi.Cluster{Server: "https://127.0.0.1:6443"}
+	config.AuthInfos["kind-"+name] = &clientcmdapi.AuthInfo{Token: name}
+	config.Contexts["kind-"+name] = &clientcmdapi.Context{Cluster: "kind-" + name, AuthInfo: "kind-" + name}
+	config.CurrentContext = "kind-" + name
+
+	content, err := clientcmd.Write(*config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func newTestClientsetCache() *clientsetCache {
+	return &clientsetCache{clientsets: make(map[[sha256.Size]byte]*kubernetes.Clientset)}
+}
+
+func TestClientsetCache(t *testing.T) {
+	cache := newTestClientsetCache()
+	dev, staging := testKubeconfig(t, "dev"), testKubeconfig(t, "staging")
+
+	first, err := cache.get(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := cache.get(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != again {
+		t.Error("the same kubeconfig got a new clientset")
+	}
+
+	other, err := cache.get(staging)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == first {
+		t.Error("different kubeconfigs share a clientset")
+	}
+
+	cache.evict(dev)
+	if len(cache.clientsets) != 1 {
+		t.Errorf("cache holds %d clientsets after evicting one of two, want 1", len(cache.clientsets))
+	}
+
+	rebuilt, err := cache.get(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rebuilt == first {
+		t.Error("an evicted clientset was returned again")
+	}
+
+	// Evicting a kubeconfig that was never cached is a no-op.
+	cache.evict(testKubeconfig(t, "unknown"))
+	if len(cache.clientsets) != 2 {
+		t.Errorf("cache holds %d clientsets, want 2", len(cache.clientsets))
+	}
+}
+
+func TestClientsetCacheInvalidKubeconfig(t *testing.T) {
+	cache := newTestClientsetCache()
+
+	if _, err := cache.get("not: [a kubeconfig"); err == nil {
+		t.Error("get() succeeded on invalid kubeconfig content")
+	}
+	if len(cache.clientsets) != 0 {
+		t.Error("a failed build was cached")
+	}
+}
+
+func BenchmarkClientsetCacheGet(b *testing.B) {
+	cache := newTestClientsetCache()
+	kubeconfig := testKubeconfig(b, "dev")
+	if _, err := cache.get(kubeconfig); err != nil {
+		b.Fatal(err)
+	}
+
+	for b.Loop() {
+		if _, err := cache.get(kubeconfig); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkClientsetCacheGetParallel(b *testing.B) {
+	cache := newTestClientsetCache()
+	kubeconfigs := make([]string, 8)
+	for i := range kubeconfigs {
+		kubeconfigs[i] = testKubeconfig(b, fmt.Sprintf("cluster-%d", i))
+	}
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if _, err := cache.get(kubeconfigs[i%len(kubeconfigs)]); err != nil {
+				b.Error(err)
+				return
+			}
+			i++
+		}
+	})
+}
+
+// BenchmarkClientsetCacheChurn creates, uses and deletes clusters the way a
+// long-running provider process does; with eviction the cache stays empty.
+func BenchmarkClientsetCacheChurn(b *testing.B) {
+	cache := newTestClientsetCache()
+
+	i := 0
+	for b.Loop() {
+		kubeconfig := testKubeconfig(b, fmt.Sprintf("cluster-%d", i))
+		if _, err := cache.get(kubeconfig); err != nil {
+			b.Fatal(err)
+		}
+		cache.evict(kubeconfig)
+		i++
+	}
+
+	if len(cache.clientsets) != 0 {
+		b.Errorf("cache holds %d clientsets after every cluster was deleted", len(cache.clientsets))
+	}
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// loadBalancerEndpoint returns the host URL of the API server load balancer
+// KinD puts in front of several control-plane nodes, read from the port its
+// container publishes for the API server.
+func loadBalancerEndpoint(ctx context.Context, runtime, containerName string) (string, error) {
+	inspected, err := inspectContainer(ctx, runtime, containerName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, binding := range inspected.HostConfig.PortBindings[strconv.Itoa(apiServerContainerPort)+"/tcp"] {
+		if binding.HostPort == "" {
+			continue
+		}
+
+		host := binding.HostIP
+		switch host {
+		case "", "0.0.0.0":
+			host = "127.0.0.1"
+		case "::":
+			host = "::1"
+		}
+
+		return "https://" + net.JoinHostPort(host, binding.HostPort), nil
+	}
+
+	return "", fmt.Errorf("load balancer %s does not publish the API server port %d", containerName, apiServerContainerPort)
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// lbInspect is `docker inspect` output of a KinD external load balancer,
+// trimmed to the fields the provider reads, with the port binding replaced.
+func lbInspect(portBindings string) string {
+	return `[{
+  "Id": "9f3c1e0b7a2d",
+  "Config": {"Image": "docker.io/kindest/haproxy:v20251120-2e5e5a55", "Env": ["PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"]},
+  "HostConfig": {
+    "Binds": null,
+    "PortBindings": ` + portBindings + `,
+    "UsernsMode": ""
+  },
+  "NetworkSettings": {"Networks": {"kind": {"IPAddress": "172.18.0.5", "GlobalIPv6Address": "fc00:f853:ccd:e793::5"}}}
+}]`
+}
+
+func TestLoadBalancerEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		portBindings string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "loopback",
+			portBindings: `{"6443/tcp": [{"HostIp": "127.0.0.1", "HostPort": "41235"}]}`,
+			want:         "https://127.0.0.1:41235",
+		},
+		{
+			name:         "all interfaces",
+			portBindings: `{"6443/tcp": [{"HostIp": "0.0.0.0", "HostPort": "6443"}]}`,
+			want:         "https://127.0.0.1:6443",
+		},
+		{
+			name:         "podman without host ip",
+			portBindings: `{"6443/tcp": [{"HostIp": "", "HostPort": "38211"}]}`,
+			want:         "https://127.0.0.1:38211",
+		},
+		{
+			name:         "ipv6 all interfaces",
+			portBindings: `{"6443/tcp": [{"HostIp": "::", "HostPort": "40001"}]}`,
+			want:         "https://[::1]:40001",
+		},
+		{
+			name:         "ipv6 address",
+			portBindings: `{"6443/tcp": [{"HostIp": "fd00::1", "HostPort": "40001"}]}`,
+			want:         "https://[fd00::1]:40001",
+		},
+		{
+			name:         "first published binding",
+			portBindings: `{"6443/tcp": [{"HostIp": "127.0.0.1", "HostPort": ""}, {"HostIp": "192.168.1.10", "HostPort": "7443"}]}`,
+			want:         "https://192.168.1.10:7443",
+		},
+		{
+			name:         "other ports only",
+			portBindings: `{"80/tcp": [{"HostIp": "0.0.0.0", "HostPort": "8080"}]}`,
+			wantErr:      true,
+		},
+		{
+			name:         "no bindings",
+			portBindings: `{}`,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime, _ := fakeRuntime(t, lbInspect(tt.portBindings))
+
+			got, err := loadBalancerEndpoint(context.Background(), runtime, "dev-external-load-balancer")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadBalancerEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("loadBalancerEndpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerEndpointInspectFailure(t *testing.T) {
+	runtime, _ := fakeRuntime(t, "Error: No such object: dev-external-load-balancer")
+
+	if _, err := loadBalancerEndpoint(context.Background(), runtime, "dev-external-load-balancer"); err == nil {
+		t.Error("loadBalancerEndpoint() succeeded on unparseable inspect output")
+	}
+}
+
+func TestBuildClusterConfigMultipleControlPlanes(t *testing.T) {
+	node := func(role string) NodeModel {
+		return NodeModel{Role: types.StringValue(role)}
+	}
+	data := &ClusterResourceModel{
+		Name:  types.StringValue("dev"),
+		Nodes: []NodeModel{node("control-plane"), node("control-plane"), node("control-plane"), node("worker")},
+	}
+
+	cfg := (&ClusterResource{}).buildClusterConfig(data)
+
+	var roles []v1alpha4.NodeRole
+	for _, n := range cfg.Nodes {
+		roles = append(roles, n.Role)
+	}
+	wantRoles := []v1alpha4.NodeRole{v1alpha4.ControlPlaneRole, v1alpha4.ControlPlaneRole, v1alpha4.ControlPlaneRole, v1alpha4.WorkerRole}
+	if !slices.Equal(roles, wantRoles) {
+		t.Fatalf("buildClusterConfig() node roles = %v, want %v", roles, wantRoles)
+	}
+
+	// KinD adds the load balancer itself for more than one control plane, so
+	// the config holds only the configured nodes, named in KinD's order.
+	wantNames := []string{"dev-control-plane", "dev-control-plane2", "dev-control-plane3", "dev-worker"}
+	if names := nodeContainerNames("dev", cfg.Nodes); !slices.Equal(names, wantNames) {
+		t.Errorf("nodeContainerNames() = %v, want %v", names, wantNames)
+	}
+}
+
+// testAccCheckLoadBalancerReachable reads the API server version through
+// load_balancer_endpoint with the credentials of the resource's kubeconfig.
+func testAccCheckLoadBalancerReachable(resourceName string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		config, err := clientcmd.RESTConfigFromKubeConfig([]byte(rs.Primary.Attributes["kubeconfig"]))
+		if err != nil {
+			return err
+		}
+		config.Host = rs.Primary.Attributes["load_balancer_endpoint"]
+
+		clientset, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return err
+		}
+		if _, err := clientset.Discovery().ServerVersion(); err != nil {
+			return fmt.Errorf("API server not reachable through the load balancer at %s: %w", config.Host, err)
+		}
+
+		return nil
+	}
+}
+
+// TestAccClusterResourceHAControlPlane creates a cluster with three control
+// planes and checks the load balancer KinD puts in front of them.
+func TestAccClusterResourceHAControlPlane(t *testing.T) {
+	address := "kind_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "kind_cluster" "test" {
+  name = "tf-acc-ha"
+
+  node {
+    role = "control-plane"
+  }
+
+  node {
+    role = "control-plane"
+  }
+
+  node {
+    role = "control-plane"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(address, "load_balancer_endpoint", regexp.MustCompile(`^https://.+:[0-9]+$`)),
+					resource.TestCheckTypeSetElemNestedAttrs(address, "node_names.*", map[string]string{
+						"container_name":       "tf-acc-ha-external-load-balancer",
+						"role":                 "external-load-balancer",
+						"kubernetes_node_name": "",
+					}),
+					testAccCheckReadyNodes(address, 3),
+					testAccCheckLoadBalancerReachable(address),
+				),
+			},
+		},
+	})
+}
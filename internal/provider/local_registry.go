@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// defaultLocalRegistryName is the registry container name used when
+	// local_registry.name is not set, as in KinD's local registry guide.
+	defaultLocalRegistryName = "kind-registry"
+	// defaultLocalRegistryPort is the host port used when local_registry.port
+	// is not set.
+	defaultLocalRegistryPort = 5001
+	// defaultLocalRegistryImage is the registry image used when
+	// local_registry.image is not set.
+	defaultLocalRegistryImage = "registry:2"
+	// localRegistryContainerPort is the port the registry image listens on.
+	localRegistryContainerPort = 5000
+	// localRegistryHostingConfigMap is the ConfigMap in kube-public through
+	// which tools discover the registry, as described by KEP-1755.
+	localRegistryHostingConfigMap = "local-registry-hosting"
+	// localRegistryHostingKey is the ConfigMap key of the KEP-1755 document.
+	localRegistryHostingKey = "localRegistryHosting.v1"
+	// localRegistryPrivateKey marks, in private state, a registry container
+	// the resource created and so removes on delete.
+	localRegistryPrivateKey = "local_registry_created"
+)
+
+// localRegistrySettings returns the registry's container name, host port and
+// image with the defaults filled in.
+func localRegistrySettings(registry *LocalRegistryModel) (string, int64, string) {
+	name := registry.Name.ValueString()
+	if name == "" {
+		name = defaultLocalRegistryName
+	}
+
+	port := int64(defaultLocalRegistryPort)
+	if !registry.Port.IsNull() {
+		port = registry.Port.ValueInt64()
+	}
+
+	image := registry.Image.ValueString()
+	if image == "" {
+		image = defaultLocalRegistryImage
+	}
+
+	return name, port, image
+}
+
+// localRegistryHost is the registry address images are pushed to from the
+// host and referenced by in pod specs.
+func localRegistryHost(registry *LocalRegistryModel) string {
+	_, port, _ := localRegistrySettings(registry)
+	return "localhost:" + strconv.FormatInt(port, 10)
+}
+
+// registryMirrors returns the configured registry mirrors plus the one
+// sending pulls of localhost:<port> images to the local registry container,
+// which the nodes reach by name on the KinD network.
+func registryMirrors(data *ClusterResourceModel) []RegistryMirrorModel {
+	if data.LocalRegistry == nil {
+		return data.RegistryMirrors
+	}
+
+	name, _, _ := localRegistrySettings(data.LocalRegistry)
+	mirror := fmt.Sprintf("http://%s:%d", name, localRegistryContainerPort)
+
+	return append(slices.Clone(data.RegistryMirrors), RegistryMirrorModel{
+		Endpoint: types.StringValue(localRegistryHost(data.LocalRegistry)),
+		Mirrors:  types.ListValueMust(types.StringType, []attr.Value{types.StringValue(mirror)}),
+	})
+}
+
+// startLocalRegistry runs the registry container on the KinD network,
+// publishing it on the loopback interface of the host. An existing container
+// with the same name, such as one shared with another cluster, is started if
+// needed and connected to the network instead. It reports whether the
+// container was created here.
+func startLocalRegistry(ctx context.Context, runtime string, registry *LocalRegistryModel) (bool, error) {
+	name, port, image := localRegistrySettings(registry)
+	network := kindNetworkName()
+
+	existing, err := runContainerCommand(ctx, runtime, "ps", "-a", "--filter", "name=^"+name+"$", "--format", "{{.Names}}")
+	if err != nil {
+		return false, err
+	}
+
+	if strings.TrimSpace(existing) == "" {
+		_, err := runContainerCommand(ctx, runtime, "run", "-d", "--restart=always",
+			"--name", name,
+			"--network", network,
+			"-p", fmt.Sprintf("127.0.0.1:%d:%d", port, localRegistryContainerPort),
+			image)
+		return err == nil, err
+	}
+
+	if _, err := runContainerCommand(ctx, runtime, "start", name); err != nil {
+		return false, err
+	}
+
+	inspected, err := inspectContainer(ctx, runtime, name)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := inspected.NetworkSettings.Networks[network]; ok {
+		return false, nil
+	}
+
+	_, err = runContainerCommand(ctx, runtime, "network", "connect", network, name)
+	return false, err
+}
+
+// removeLocalRegistry removes the registry container and its storage. It is
+// only called for containers the resource created, never for shared ones. A
+// container that is already gone is not an error.
+func removeLocalRegistry(ctx context.Context, runtime string, registry *LocalRegistryModel) error {
+	name, _, _ := localRegistrySettings(registry)
+
+	existing, err := runContainerCommand(ctx, runtime, "ps", "-a", "--filter", "name=^"+name+"$", "--format", "{{.Names}}")
+	if err != nil || strings.TrimSpace(existing) == "" {
+		return err
+	}
+
+	_, err = runContainerCommand(ctx, runtime, "rm", "-f", "-v", name)
+	return err
+}
+
+// createLocalRegistryHostingConfigMap publishes the registry address in the
+// local-registry-hosting ConfigMap, for tools such as Tilt and Skaffold to
+// push images to. An existing ConfigMap is left in place.
+func createLocalRegistryHostingConfigMap(ctx context.Context, clientset kubernetes.Interface, registry *LocalRegistryModel) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: localRegistryHostingConfigMap, Namespace: metav1.NamespacePublic},
+		Data: map[string]string{
+			localRegistryHostingKey: fmt.Sprintf("host: %q\nhelp: \"https://kind.sigs.k8s.io/docs/user/local-registry/\"\n", localRegistryHost(registry)),
+		},
+	}
+
+	_, err := clientset.CoreV1().ConfigMaps(metav1.NamespacePublic).Create(ctx, configMap, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create ConfigMap %q in namespace %q: %w", localRegistryHostingConfigMap, metav1.NamespacePublic, err)
+	}
+
+	return nil
+}
+
+// validateLocalRegistry checks the registry container name and rejects a
+// registry_mirror for the address the local registry is served at.
+func validateLocalRegistry(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	registry := data.LocalRegistry
+	if registry == nil {
+		return
+	}
+
+	if !registry.Name.IsNull() && !registry.Name.IsUnknown() && strings.TrimSpace(registry.Name.ValueString()) == "" {
+		diagnostics.AddAttributeError(
+			path.Root("local_registry").AtName("name"),
+			"Invalid Registry Name",
+			"local_registry.name must not be blank. Leave it unset to use "+defaultLocalRegistryName+".",
+		)
+	}
+
+	if registry.Port.IsUnknown() {
+		return
+	}
+
+	host := localRegistryHost(registry)
+	for i, mirror := range data.RegistryMirrors {
+		if mirror.Endpoint.ValueString() == host {
+			diagnostics.AddAttributeError(
+				path.Root("registry_mirror").AtListIndex(i).AtName("endpoint"),
+				"Duplicate Registry Mirror",
+				fmt.Sprintf("Registry %q is served by local_registry and cannot have a registry_mirror block.", host),
+			)
+		}
+	}
+}
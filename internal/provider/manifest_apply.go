@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+)
+
+const (
+	// defaultManifestApplyRetries is how many times a failed apply is retried.
+	defaultManifestApplyRetries = 3
+
+	// defaultManifestApplyTimeout is the time in seconds one apply attempt
+	// may take.
+	defaultManifestApplyTimeout = 120
+)
+
+// manifestApplier runs the post-create applies of objects and manifests,
+// retrying failures that may be transient. Right after creation the API
+// server and freshly installed webhooks often refuse or time out requests for
+// a while, especially on slow hosts.
+type manifestApplier struct {
+	retries int
+	timeout time.Duration
+}
+
+// newManifestApplier returns the applier configured by manifest_apply_retries
+// and manifest_apply_timeout.
+func newManifestApplier(data *ClusterResourceModel) manifestApplier {
+	return manifestApplier{
+		retries: int(data.ManifestApplyRetries.ValueInt64()),
+		timeout: time.Duration(data.ManifestApplyTimeout.ValueInt64()) * time.Second,
+	}
+}
+
+// run calls apply until it succeeds, giving each attempt its own timeout and
+// backing off between attempts. Errors the API server returns for the object
+// itself, such as validation failures, are not retried.
+func (a manifestApplier) run(ctx context.Context, apply func(ctx context.Context) error) error {
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, a.timeout)
+		err = apply(attemptCtx)
+		cancel()
+
+		if err == nil || attempt > a.retries || isPermanentApplyError(err) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (gave up after %d attempt(s): %s)", err, attempt, ctx.Err())
+		case <-time.After(time.Duration(attempt) * 2 * time.Second):
+		}
+	}
+
+	if err != nil && attempt > 1 {
+		return fmt.Errorf("%w (after %d attempts)", err, attempt)
+	}
+
+	return err
+}
+
+// applyManifestInNode applies a multi-document manifest with the kubectl and
+// admin kubeconfig of a control-plane node. Server-side apply is used since
+// CRDs are often too large for the last-applied annotation of client-side
+// apply.
+func applyManifestInNode(ctx context.Context, node nodes.Node, manifest []byte) error {
+	var output bytes.Buffer
+	cmd := node.CommandContext(ctx,
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf", "apply", "--server-side", "--force-conflicts", "-f", "-",
+	)
+	cmd.SetStdin(bytes.NewReader(manifest))
+	cmd.SetStdout(&output)
+	cmd.SetStderr(&output)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %s", err, lastLines(output.String(), 20))
+	}
+
+	return nil
+}
+
+// isPermanentApplyError reports whether err is a rejection of the object
+// itself, which retrying cannot fix.
+func isPermanentApplyError(err error) bool {
+	return apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) || apierrors.IsForbidden(err) || apierrors.IsNotFound(err)
+}
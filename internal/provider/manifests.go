@@ -0,0 +1,322 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+// manifestFieldManager is the server-side apply field manager of the objects
+// from apply_manifests.
+const manifestFieldManager = "terraform-provider-kind"
+
+// isInlineManifest reports whether an apply_manifests element is YAML rather
+// than a file path: inline manifests span several lines or are JSON objects.
+func isInlineManifest(manifest string) bool {
+	return strings.Contains(manifest, "\n") || strings.HasPrefix(strings.TrimSpace(manifest), "{")
+}
+
+// manifestObject is one document of an apply_manifests element.
+type manifestObject struct {
+	// source names the element and document for error messages.
+	source string
+	obj    *unstructured.Unstructured
+}
+
+// parseManifests reads the apply_manifests elements and splits them into
+// their objects, in order. Empty documents are skipped. Errors are returned
+// per element so one unreadable manifest does not hide the others.
+func parseManifests(manifests []string) ([]manifestObject, []error) {
+	var objects []manifestObject
+	var errs []error
+	for i, manifest := range manifests {
+		content := manifest
+		label := fmt.Sprintf("apply_manifests[%d]", i)
+		if !isInlineManifest(manifest) {
+			label = fmt.Sprintf("apply_manifests[%d] (%s)", i, manifest)
+			file, err := expandPath(manifest)
+			if err == nil {
+				var data []byte
+				data, err = os.ReadFile(file)
+				content = string(data)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", label, err))
+				continue
+			}
+		}
+
+		docObjects, docErrs := parseManifestDocuments(label, content)
+		objects = append(objects, docObjects...)
+		errs = append(errs, docErrs...)
+	}
+
+	return objects, errs
+}
+
+// parseManifestDocuments splits multi-document YAML into its objects, in
+// order, labelling each with label and its document index. Empty documents
+// are skipped.
+func parseManifestDocuments(label, content string) ([]manifestObject, []error) {
+	var objects []manifestObject
+	var errs []error
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(content)))
+	for doc := 0; ; doc++ {
+		raw, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s document %d: %w", label, doc, err))
+			break
+		}
+
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal(raw, &obj); err != nil {
+			errs = append(errs, fmt.Errorf("%s document %d: %w", label, doc, err))
+			continue
+		}
+		if len(obj) == 0 {
+			continue
+		}
+
+		u := &unstructured.Unstructured{Object: obj}
+		if u.GetAPIVersion() == "" || u.GetKind() == "" || u.GetName() == "" {
+			errs = append(errs, fmt.Errorf("%s document %d: apiVersion, kind and metadata.name are required", label, doc))
+			continue
+		}
+		objects = append(objects, manifestObject{source: fmt.Sprintf("%s document %d", label, doc), obj: u})
+	}
+
+	return objects, errs
+}
+
+// manifestClient applies and deletes arbitrary objects. Its REST mapping is
+// rediscovered when a kind is unknown, so custom resources can follow the
+// CRDs that define them in the same run.
+type manifestClient struct {
+	client dynamic.Interface
+	mapper meta.RESTMapper
+	// rediscover rebuilds mapper from the current API discovery.
+	rediscover func() error
+}
+
+// newManifestClient returns a manifestClient for kubeconfig content.
+func newManifestClient(kubeconfigContent string) (*manifestClient, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfigContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubeconfig: %w", err)
+	}
+	config.Timeout = kubernetesRequestTimeout
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	clientset, err := sharedClientsets.get(kubeconfigContent)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &manifestClient{client: client}
+	c.rediscover = func() error {
+		groups, err := restmapper.GetAPIGroupResources(clientset.Discovery())
+		if err != nil {
+			return fmt.Errorf("failed to discover API resources: %w", err)
+		}
+		c.mapper = restmapper.NewDiscoveryRESTMapper(groups)
+		return nil
+	}
+
+	if err := c.rediscover(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// resourceFor returns the dynamic resource client for an object, defaulting
+// the namespace of namespaced objects.
+func (c *manifestClient) resourceFor(obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := c.mapper.RESTMapping(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}, gvk.Version)
+	if meta.IsNoMatchError(err) {
+		if err := c.rediscover(); err != nil {
+			return nil, err
+		}
+		mapping, err = c.mapper.RESTMapping(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}, gvk.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		obj.SetNamespace("")
+		return c.client.Resource(mapping.Resource), nil
+	}
+
+	obj.SetNamespace(namespaceOrDefault(obj.GetNamespace()))
+	return c.client.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
+
+// apply server-side applies an object.
+func (c *manifestClient) apply(ctx context.Context, obj *unstructured.Unstructured) error {
+	resource, err := c.resourceFor(obj)
+	if err != nil {
+		return err
+	}
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	force := true
+	_, err = resource.Patch(ctx, obj.GetName(), k8stypes.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: manifestFieldManager,
+		Force:        &force,
+	})
+	return err
+}
+
+// delete deletes an object, ignoring objects and kinds that are already gone.
+func (c *manifestClient) delete(ctx context.Context, ref AppliedManifestModel) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion.ValueString())
+	obj.SetKind(ref.Kind.ValueString())
+	obj.SetNamespace(ref.Namespace.ValueString())
+	obj.SetName(ref.Name.ValueString())
+
+	resource, err := c.resourceFor(obj)
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := resource.Delete(ctx, obj.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// applyManifests applies the apply_manifests objects in order and records
+// the ones applied in applied_manifests. Every document that fails is
+// reported as its own error and the remaining documents are still applied.
+// Objects recorded by a previous apply that are no longer in the manifests
+// are deleted, unless a manifest failed to load.
+func applyManifests(ctx context.Context, data *ClusterResourceModel, previous []AppliedManifestModel, diagnostics *diag.Diagnostics) {
+	objects, errs := parseManifests(listStringValues(data.ApplyManifests))
+	for _, err := range errs {
+		diagnostics.AddError("Failed to read manifest", err.Error())
+	}
+
+	client, err := newManifestClient(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddError("Failed to apply manifests", err.Error())
+		return
+	}
+
+	applier := newManifestApplier(data)
+	applied := make([]AppliedManifestModel, 0, len(objects))
+	for _, m := range objects {
+		err := applier.run(ctx, func(ctx context.Context) error {
+			return client.apply(ctx, m.obj)
+		})
+		if err != nil {
+			diagnostics.AddError(
+				"Failed to apply manifest",
+				fmt.Sprintf("%s, %s %s: %s", m.source, m.obj.GetKind(), m.obj.GetName(), err),
+			)
+			continue
+		}
+
+		applied = append(applied, AppliedManifestModel{
+			APIVersion: types.StringValue(m.obj.GetAPIVersion()),
+			Kind:       types.StringValue(m.obj.GetKind()),
+			Namespace:  types.StringValue(m.obj.GetNamespace()),
+			Name:       types.StringValue(m.obj.GetName()),
+		})
+	}
+
+	// Objects that failed to apply are not pruned: they are still in the
+	// manifests and may exist from before.
+	kept := map[string]bool{}
+	for _, m := range objects {
+		kept[manifestObjectKey(m.obj.GetAPIVersion(), m.obj.GetKind(), m.obj.GetNamespace(), m.obj.GetName())] = true
+	}
+	for i := len(previous) - 1; i >= 0; i-- {
+		ref := previous[i]
+		if kept[manifestObjectKey(ref.APIVersion.ValueString(), ref.Kind.ValueString(), ref.Namespace.ValueString(), ref.Name.ValueString())] {
+			continue
+		}
+		// A manifest that could not be read may still hold the object, so
+		// nothing is pruned and it stays recorded for the next apply.
+		if len(errs) > 0 {
+			applied = append(applied, ref)
+			continue
+		}
+		if err := client.delete(ctx, ref); err != nil {
+			diagnostics.AddWarning(
+				"Failed to delete removed manifest object",
+				fmt.Sprintf("%s %s: %s", ref.Kind.ValueString(), ref.Name.ValueString(), err),
+			)
+		}
+	}
+
+	value, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: appliedManifestAttrTypes}, applied)
+	diagnostics.Append(d...)
+	data.AppliedManifests = value
+}
+
+// deleteManifests deletes the objects recorded in applied_manifests in
+// reverse order, so objects are removed before the namespaces and CRDs they
+// depend on. Failures are warnings since the cluster is deleted next anyway.
+func deleteManifests(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	var applied []AppliedManifestModel
+	diagnostics.Append(data.AppliedManifests.ElementsAs(ctx, &applied, false)...)
+	if len(applied) == 0 {
+		return
+	}
+
+	client, err := newManifestClient(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddWarning("Failed to delete manifests", err.Error())
+		return
+	}
+
+	for i := len(applied) - 1; i >= 0; i-- {
+		if err := client.delete(ctx, applied[i]); err != nil {
+			diagnostics.AddWarning(
+				"Failed to delete manifest object",
+				fmt.Sprintf("%s %s: %s", applied[i].Kind.ValueString(), applied[i].Name.ValueString(), err),
+			)
+		}
+	}
+}
+
+// manifestObjectKey identifies an object across applies.
+func manifestObjectKey(apiVersion, kind, namespace, name string) string {
+	return strings.Join([]string{apiVersion, kind, namespace, name}, "/")
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// recordMetrics appends Prometheus textfile-collector samples for a cluster
+// operation to the provider's metrics file. The result is derived from the
+// diagnostics collected so far. Failures to write only produce a warning.
+func (r *ClusterResource) recordMetrics(clusterName, operation string, start time.Time, nodeCount int, diagnostics *diag.Diagnostics) {
+	if r.metricsFile == "" {
+		return
+	}
+
+	result := "success"
+	if diagnostics.HasError() {
+		result = "failure"
+	}
+
+	labels := fmt.Sprintf(`cluster="%s",operation="%s",result="%s"`,
+		prometheusLabelEscaper.Replace(clusterName), operation, result)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "kind_cluster_operation_duration_seconds{%s} %.3f\n", labels, time.Since(start).Seconds())
+	fmt.Fprintf(&sb, "kind_cluster_operation_nodes{%s} %d\n", labels, nodeCount)
+
+	if err := appendToFile(r.metricsFile, sb.String()); err != nil {
+		diagnostics.AddWarning("Failed to write metrics file", err.Error())
+	}
+}
+
+// appendToFile appends content to path in a single write so that concurrent
+// writers using O_APPEND do not interleave their records.
+func appendToFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
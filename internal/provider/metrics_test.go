@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestRecordMetrics(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "kind.prom")
+	r := &ClusterResource{metricsFile: file}
+
+	for _, operation := range []string{"create", "update", "delete"} {
+		var diagnostics diag.Diagnostics
+		if operation == "update" {
+			diagnostics.AddError("Update failed", "boom")
+		}
+		r.recordMetrics(`dev"1`, operation, time.Now(), 2, &diagnostics)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`kind_cluster_operation_nodes{cluster="dev\"1",operation="create",result="success"} 2`,
+		`kind_cluster_operation_nodes{cluster="dev\"1",operation="update",result="failure"} 2`,
+		`kind_cluster_operation_nodes{cluster="dev\"1",operation="delete",result="success"} 2`,
+		`kind_cluster_operation_duration_seconds{cluster="dev\"1",operation="delete",result="success"} `,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("metrics file is missing %s:\n%s", want, content)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+// applyNamespacePolicy creates the namespace if needed, then its ResourceQuota
+// and container LimitRange. Objects that already exist are left in place.
+func applyNamespacePolicy(ctx context.Context, clientset kubernetes.Interface, policy *NamespacePolicyModel) error {
+	namespace := policy.Namespace.ValueString()
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	if _, err := clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %q: %w", namespace, err)
+	}
+
+	if hard := resourceList(policy.ResourceQuota); len(hard) > 0 {
+		quota := &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace + "-quota", Namespace: namespace},
+			Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+		}
+		if _, err := clientset.CoreV1().ResourceQuotas(namespace).Create(ctx, quota, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create ResourceQuota in namespace %q: %w", namespace, err)
+		}
+	}
+
+	item := corev1.LimitRangeItem{
+		Type:           corev1.LimitTypeContainer,
+		Default:        resourceList(policy.LimitDefault),
+		DefaultRequest: resourceList(policy.LimitDefaultRequest),
+		Max:            resourceList(policy.LimitMax),
+		Min:            resourceList(policy.LimitMin),
+	}
+	if len(item.Default)+len(item.DefaultRequest)+len(item.Max)+len(item.Min) > 0 {
+		limitRange := &corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace + "-limits", Namespace: namespace},
+			Spec:       corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{item}},
+		}
+		if _, err := clientset.CoreV1().LimitRanges(namespace).Create(ctx, limitRange, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create LimitRange in namespace %q: %w", namespace, err)
+		}
+	}
+
+	return nil
+}
+
+// resourceList converts a map of resource names to quantities. Values are
+// checked by quantityValidator, so unparseable entries are skipped.
+func resourceList(m types.Map) corev1.ResourceList {
+	values := stringMapValue(m)
+	if len(values) == 0 {
+		return nil
+	}
+
+	list := make(corev1.ResourceList, len(values))
+	for name, value := range values {
+		if quantity, err := resource.ParseQuantity(value); err == nil {
+			list[corev1.ResourceName(name)] = quantity
+		}
+	}
+
+	return list
+}
+
+// validateNamespacePolicies checks namespace names and rejects policies
+// declared twice for the same namespace.
+func validateNamespacePolicies(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	seen := map[string]bool{}
+	for i, policy := range data.NamespacePolicies {
+		if policy.Namespace.IsUnknown() {
+			continue
+		}
+
+		namespace := policy.Namespace.ValueString()
+		attrPath := path.Root("namespace_policies").AtListIndex(i).AtName("namespace")
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			diagnostics.AddAttributeError(
+				attrPath,
+				"Invalid Namespace",
+				fmt.Sprintf("%q is not a valid namespace name: %s", namespace, strings.Join(errs, "; ")),
+			)
+		}
+
+		if seen[namespace] {
+			diagnostics.AddAttributeError(
+				attrPath,
+				"Duplicate Namespace Policy",
+				fmt.Sprintf("Namespace %q has more than one namespace_policies block.", namespace),
+			)
+		}
+		seen[namespace] = true
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// nodeRoles are the node roles KinD supports.
+var nodeRoles = []string{string(v1alpha4.ControlPlaneRole), string(v1alpha4.WorkerRole)}
+
+// nodeUpdateFlags are the container runtime `update` flags accepted in
+// node.extra_args. Anything else cannot be changed on a running container.
+var nodeUpdateFlags = []string{
+	"blkio-weight", "cpu-period", "cpu-quota", "cpu-rt-period", "cpu-rt-runtime",
+	"cpu-shares", "cpus", "cpuset-cpus", "cpuset-mems", "memory",
+	"memory-reservation", "memory-swap", "pids-limit", "restart",
+}
+
+// applyNodeExtraArgs updates the node containers with their configured extra
+// arguments. KinD has no option to pass flags to the node containers, so they
+// are applied with the container runtime's update command after creation.
+func applyNodeExtraArgs(ctx context.Context, runtime, clusterName string, nodes []NodeModel, cfgNodes []v1alpha4.Node, diagnostics *diag.Diagnostics) {
+	containerNames := nodeContainerNames(clusterName, cfgNodes)
+	for i, node := range nodes {
+		args := listStringValues(node.ExtraArgs)
+		if len(args) == 0 {
+			continue
+		}
+
+		updateArgs := append([]string{"update"}, args...)
+		updateArgs = append(updateArgs, containerNames[i])
+		if _, err := runContainerCommand(ctx, runtime, updateArgs...); err != nil {
+			diagnostics.AddError(
+				"Failed to apply node extra_args",
+				fmt.Sprintf("Updating node container %s failed: %s", containerNames[i], err),
+			)
+		}
+	}
+}
+
+// validateNodeExtraArgs checks that node.extra_args only contain flags the
+// container runtime can update on a running container, and warns that they
+// are outside what KinD supports.
+func validateNodeExtraArgs(nodes []NodeModel, diagnostics *diag.Diagnostics) {
+	for i, node := range nodes {
+		if node.ExtraArgs.IsNull() || node.ExtraArgs.IsUnknown() {
+			continue
+		}
+
+		attrPath := path.Root("node").AtListIndex(i).AtName("extra_args")
+		for _, arg := range listStringValues(node.ExtraArgs) {
+			name, _, _ := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+			if !strings.HasPrefix(arg, "--") || !slices.Contains(nodeUpdateFlags, name) {
+				diagnostics.AddAttributeError(
+					attrPath,
+					"Unsupported Node Argument",
+					fmt.Sprintf("%q is not supported. Use --flag=value with one of: %s.", arg, strings.Join(nodeUpdateFlags, ", ")),
+				)
+			}
+		}
+
+		diagnostics.AddAttributeWarning(
+			attrPath,
+			"Advanced Node Configuration",
+			"extra_args are applied to the node container with the container runtime's update command after KinD creates it. "+
+				"KinD does not support this and misconfigured limits can leave the node unable to run Kubernetes.",
+		)
+	}
+}
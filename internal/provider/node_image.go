@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/apis/config/defaults"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	kindversion "sigs.k8s.io/kind/pkg/cmd/kind/version"
+)
+
+// nodeImageRepository is the repository KinD publishes node images to.
+const nodeImageRepository = "kindest/node"
+
+// defaultNodeImageVersion returns the Kubernetes version of the node image the
+// compiled-in kind library defaults to, without the leading v.
+func defaultNodeImageVersion() string {
+	tag, _, _ := strings.Cut(strings.TrimPrefix(defaults.Image, nodeImageRepository+":"), "@")
+	return strings.TrimPrefix(tag, "v")
+}
+
+// usedNodeImage returns the image KinD created the nodes without a per-node
+// image from, as their container reports it. KinD's default image is returned
+// when every node sets its own.
+func usedNodeImage(ctx context.Context, runtime, clusterName string, nodes []v1alpha4.Node) (string, error) {
+	names := nodeContainerNames(clusterName, nodes)
+	for i, node := range nodes {
+		if node.Image != "" {
+			continue
+		}
+
+		inspected, err := inspectContainer(ctx, runtime, names[i])
+		if err != nil {
+			return "", err
+		}
+
+		return inspected.Config.Image, nil
+	}
+
+	return defaults.Image, nil
+}
+
+// kindLibraryVersion returns the core version of the compiled-in kind library.
+func kindLibraryVersion() string {
+	version, _, _ := strings.Cut(strings.TrimPrefix(kindversion.Version(), "v"), "-")
+	return version
+}
+
+// knownNodeImages returns the pinned node images for the compiled-in kind
+// release, keyed by Kubernetes version without the leading v. That is the
+// release's default image, which the library pins by digest; the other images
+// of a release are only listed in its release notes, and a copied digest that
+// drifts from them breaks every pull, so they are not tracked here.
+func knownNodeImages() map[string]string {
+	return map[string]string{defaultNodeImageVersion(): defaults.Image}
+}
+
+// nodeImageForVersion returns the pinned node image for a Kubernetes version
+// and the versions known for the compiled-in kind release.
+func nodeImageForVersion(kubernetesVersion string) (string, []string, error) {
+	images := knownNodeImages()
+
+	version := strings.TrimPrefix(kubernetesVersion, "v")
+	if image, ok := images[version]; ok {
+		return image, nil, nil
+	}
+
+	known := make([]string, 0, len(images))
+	for v := range images {
+		known = append(known, v)
+	}
+	sort.Strings(known)
+
+	return "", known, fmt.Errorf("no node image is known for Kubernetes %s with kind %s", version, kindLibraryVersion())
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &NodeImageDataSource{}
+
+type NodeImageDataSource struct{}
+
+func NewNodeImageDataSource() datasource.DataSource {
+	return &NodeImageDataSource{}
+}
+
+func (d *NodeImageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node_image"
+}
+
+func (d *NodeImageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resolve a Kubernetes version to the digest-pinned kindest/node image of the kind version built into the provider. Only the release's default image is pinned; set allow_unpinned to fall back to the plain tag for other versions.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (same as image).",
+				Computed:    true,
+			},
+			"kubernetes_version": schema.StringAttribute{
+				Description: "Kubernetes version, e.g. 1.35.0 or v1.35.0.",
+				Required:    true,
+			},
+			"allow_unpinned": schema.BoolAttribute{
+				Description: "Return the plain kindest/node:v<version> tag with a warning when no pinned image is known, instead of failing.",
+				Optional:    true,
+			},
+			"image": schema.StringAttribute{
+				Description: "Node image for the node_image attribute of kind_cluster.",
+				Computed:    true,
+			},
+			"kind_version": schema.StringAttribute{
+				Description: "Version of the kind library the provider is built with.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *NodeImageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NodeImageDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	image, known, err := nodeImageForVersion(data.KubernetesVersion.ValueString())
+	if err != nil {
+		if !data.AllowUnpinned.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("kubernetes_version"),
+				"Unknown Kubernetes Version",
+				fmt.Sprintf("%s. Known versions: %s. Set allow_unpinned = true to use the plain tag.", err, strings.Join(known, ", ")),
+			)
+			return
+		}
+
+		image = fmt.Sprintf("%s:v%s", nodeImageRepository, strings.TrimPrefix(data.KubernetesVersion.ValueString(), "v"))
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("kubernetes_version"),
+			"Unpinned Node Image",
+			fmt.Sprintf("%s, using %s. The tag may not exist or may not be built for this kind release.", err, image),
+		)
+	}
+
+	data.ID = types.StringValue(image)
+	data.Image = types.StringValue(image)
+	data.KindVersion = types.StringValue(kindLibraryVersion())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"sigs.k8s.io/kind/pkg/apis/config/defaults"
+)
+
+// imageDigestPattern matches an image content digest as registries report it.
+var imageDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// resolvedNodeImage returns the image KinD creates nodes without a per-node
+// image from: node_image, which Create fills from the provider's
+// default_node_image, or else KinD's own default.
+func resolvedNodeImage(data *ClusterResourceModel) string {
+	if image := data.NodeImage.ValueString(); image != "" {
+		return image
+	}
+
+	return defaults.Image
+}
+
+// pinnedDigestConflict returns an error when image pins a digest other than
+// digest.
+func pinnedDigestConflict(image, digest string) error {
+	if _, pinned, ok := strings.Cut(image, "@"); ok && pinned != digest {
+		return fmt.Errorf("%s already pins digest %s, which differs from node_image_digest %s", image, pinned, digest)
+	}
+
+	return nil
+}
+
+// verifyNodeImageDigest checks that image, pulled first when it is not
+// present locally, has digest among its repository digests. KinD then finds
+// the image present and creates the nodes from exactly that content.
+func verifyNodeImageDigest(ctx context.Context, runtime, image, digest string) error {
+	if err := pinnedDigestConflict(image, digest); err != nil {
+		return err
+	}
+
+	if _, err := runContainerCommand(ctx, runtime, "image", "inspect", image); err != nil {
+		if _, err := runContainerCommand(ctx, runtime, "pull", image); err != nil {
+			return fmt.Errorf("failed to pull %s: %w", image, err)
+		}
+	}
+
+	out, err := runContainerCommand(ctx, runtime, "image", "inspect", "--format", "{{json .RepoDigests}}", image)
+	if err != nil {
+		return err
+	}
+
+	var repoDigests []string
+	if err := json.Unmarshal([]byte(out), &repoDigests); err != nil {
+		return fmt.Errorf("unexpected inspect output for %s: %w", image, err)
+	}
+	if len(repoDigests) == 0 {
+		return fmt.Errorf("%s has no repository digest to compare, it was not pulled from a registry", image)
+	}
+
+	found := make([]string, 0, len(repoDigests))
+	for _, repoDigest := range repoDigests {
+		_, d, _ := strings.Cut(repoDigest, "@")
+		if d == digest {
+			return nil
+		}
+		found = append(found, d)
+	}
+
+	return fmt.Errorf("%s has digest %s, expected %s; the tag may have been moved to a different image", image, strings.Join(found, ", "), digest)
+}
+
+// validateNodeImageDigest checks the digest format and that node_image, when
+// set, does not pin a different digest itself. Without node_image the digest
+// is checked in Create against the image the nodes are created from.
+func validateNodeImageDigest(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.NodeImageDigest.IsNull() || data.NodeImageDigest.IsUnknown() {
+		return
+	}
+
+	digest := data.NodeImageDigest.ValueString()
+	if !imageDigestPattern.MatchString(digest) {
+		diagnostics.AddAttributeError(
+			path.Root("node_image_digest"),
+			"Invalid Image Digest",
+			fmt.Sprintf("%q is not an image digest, expected sha256: followed by 64 lowercase hex characters.", digest),
+		)
+		return
+	}
+
+	if data.NodeImage.IsUnknown() || data.NodeImage.ValueString() == "" {
+		return
+	}
+
+	if err := pinnedDigestConflict(data.NodeImage.ValueString(), digest); err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("node_image_digest"),
+			"Conflicting Image Digest",
+			err.Error()+".",
+		)
+	}
+}
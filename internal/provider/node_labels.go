@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// equalIgnoringExtraLabels reports whether two node list elements are equal
+// apart from extra_labels, which Update reconciles in place.
+func equalIgnoringExtraLabels(a, b attr.Value) bool {
+	aObj, aOK := a.(types.Object)
+	bObj, bOK := b.(types.Object)
+	if !aOK || !bOK || aObj.IsNull() || aObj.IsUnknown() || bObj.IsNull() || bObj.IsUnknown() {
+		return a.Equal(b)
+	}
+
+	aAttrs, bAttrs := aObj.Attributes(), bObj.Attributes()
+	if len(aAttrs) != len(bAttrs) {
+		return false
+	}
+	for name, value := range aAttrs {
+		if name == "extra_labels" {
+			continue
+		}
+		if !value.Equal(bAttrs[name]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nodeLabelsPatch returns the merge patch setting the desired labels and
+// removing the previously applied ones no longer desired, or nil when there
+// is nothing to change.
+func nodeLabelsPatch(previous, desired map[string]string) ([]byte, error) {
+	labels := map[string]interface{}{}
+	for key, value := range desired {
+		if old, ok := previous[key]; !ok || old != value {
+			labels[key] = value
+		}
+	}
+	for key := range previous {
+		if _, ok := desired[key]; !ok {
+			labels[key] = nil
+		}
+	}
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"labels": labels}})
+}
+
+// applyNodeExtraLabels patches each node's extra_labels onto its Kubernetes
+// node object, removing the ones dropped since previous. previous is nil on
+// create; nodes past its end, such as workers just added, start from no
+// labels. Labels set any other way are left alone.
+func applyNodeExtraLabels(ctx context.Context, data *ClusterResourceModel, previous []NodeModel, nodeNames []string, diagnostics *diag.Diagnostics) {
+	type nodePatch struct {
+		name  string
+		patch []byte
+	}
+
+	var patches []nodePatch
+	for i, node := range data.Nodes {
+		if i >= len(nodeNames) {
+			break
+		}
+
+		var old map[string]string
+		if i < len(previous) {
+			old = stringMapValue(previous[i].ExtraLabels)
+		}
+
+		patch, err := nodeLabelsPatch(old, stringMapValue(node.ExtraLabels))
+		if err != nil {
+			diagnostics.AddError("Failed to apply node extra_labels", err.Error())
+			return
+		}
+		if patch != nil {
+			patches = append(patches, nodePatch{name: nodeNames[i], patch: patch})
+		}
+	}
+
+	if len(patches) == 0 {
+		return
+	}
+
+	clientset, err := newKubernetesClientset(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddError("Failed to apply node extra_labels", err.Error())
+		return
+	}
+
+	for _, p := range patches {
+		if _, err := clientset.CoreV1().Nodes().Patch(ctx, p.name, k8stypes.MergePatchType, p.patch, metav1.PatchOptions{}); err != nil {
+			diagnostics.AddError("Failed to apply node extra_labels", fmt.Sprintf("Patching node %s failed: %s", p.name, err))
+			return
+		}
+	}
+}
+
+// validateNodeExtraLabels checks extra_labels keys and values against the
+// Kubernetes label syntax, and rejects keys also set through labels, which
+// removing them from extra_labels would delete.
+func validateNodeExtraLabels(nodes []NodeModel, diagnostics *diag.Diagnostics) {
+	for i, node := range nodes {
+		if node.ExtraLabels.IsNull() || node.ExtraLabels.IsUnknown() {
+			continue
+		}
+
+		registration := stringMapValue(node.Labels)
+		for key, value := range stringMapValue(node.ExtraLabels) {
+			attrPath := path.Root("node").AtListIndex(i).AtName("extra_labels").AtMapKey(key)
+
+			errs := append(validation.IsQualifiedName(key), validation.IsValidLabelValue(value)...)
+			if len(errs) > 0 {
+				diagnostics.AddAttributeError(
+					attrPath,
+					"Invalid Node Label",
+					fmt.Sprintf("%s=%q is not a valid label: %s.", key, value, strings.Join(errs, "; ")),
+				)
+			}
+
+			if _, ok := registration[key]; ok {
+				diagnostics.AddAttributeError(
+					attrPath,
+					"Duplicate Node Label",
+					fmt.Sprintf("%s is also set in labels. Set each label in either labels or extra_labels.", key),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+)
+
+// nodeLocalDNSManifestURL is the NodeLocal DNSCache addon manifest, pinned to
+// a Kubernetes release. Its __PILLAR__ placeholders are filled in at install.
+const nodeLocalDNSManifestURL = "https://raw.githubusercontent.com/kubernetes/kubernetes/v1.35.0/cluster/addons/dns/nodelocaldns/nodelocaldns.yaml"
+
+// The link-local address the cache listens on in every node, the cluster DNS
+// domain KinD leaves at the kubeadm default, and the addon's DaemonSet.
+const (
+	nodeLocalDNSIP        = "169.254.20.10"
+	clusterDNSDomain      = "cluster.local"
+	nodeLocalDNSDaemonSet = "node-local-dns"
+)
+
+// kubeProxyMode returns the configured kube-proxy mode, defaulting to
+// iptables like KinD.
+func kubeProxyMode(data *ClusterResourceModel) string {
+	if data.Networking != nil && !data.Networking.KubeProxyMode.IsNull() && !data.Networking.KubeProxyMode.IsUnknown() && data.Networking.KubeProxyMode.ValueString() != "" {
+		return data.Networking.KubeProxyMode.ValueString()
+	}
+
+	return string(v1alpha4.IPTablesProxyMode)
+}
+
+// nodeLocalDNSManifest fills in the addon manifest for the kube-dns service
+// IP. With iptables the cache also binds the service IP and intercepts
+// queries to it, so pods need no change. IPVS claims every service IP on the
+// kube-ipvs0 interface, so there the cache only binds its link-local address,
+// forwards to kube-dns directly and the kubelet points pods at it instead.
+func nodeLocalDNSManifest(manifest []byte, proxyMode, kubeDNSIP string) []byte {
+	var replacer *strings.Replacer
+	if proxyMode == string(v1alpha4.IPVSProxyMode) {
+		replacer = strings.NewReplacer(
+			"__PILLAR__LOCAL__DNS__", nodeLocalDNSIP,
+			"__PILLAR__DNS__DOMAIN__", clusterDNSDomain,
+			",__PILLAR__DNS__SERVER__", "",
+			"__PILLAR__CLUSTER__DNS__", kubeDNSIP,
+		)
+	} else {
+		replacer = strings.NewReplacer(
+			"__PILLAR__LOCAL__DNS__", nodeLocalDNSIP,
+			"__PILLAR__DNS__DOMAIN__", clusterDNSDomain,
+			"__PILLAR__DNS__SERVER__", kubeDNSIP,
+		)
+	}
+
+	return []byte(replacer.Replace(string(manifest)))
+}
+
+// installNodeLocalDNS installs NodeLocal DNSCache in front of CoreDNS, which
+// it reaches through the kube-dns-upstream service the manifest creates, and
+// waits within wait_for_ready for the cache to run on every node.
+func (r *ClusterResource) installNodeLocalDNS(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	clientset, err := newKubernetesClientset(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddError("Failed to install NodeLocal DNSCache", err.Error())
+		return
+	}
+
+	kubeDNS, err := clientset.CoreV1().Services(metav1.NamespaceSystem).Get(ctx, "kube-dns", metav1.GetOptions{})
+	if err != nil {
+		diagnostics.AddError("Failed to install NodeLocal DNSCache", fmt.Sprintf("Could not read the kube-dns service: %s", err))
+		return
+	}
+
+	manifest, err := fetchManifest(ctx, nodeLocalDNSManifestURL)
+	if err != nil {
+		diagnostics.AddError("Failed to install NodeLocal DNSCache", fmt.Sprintf("Downloading %s failed: %s", nodeLocalDNSManifestURL, err))
+		return
+	}
+	manifest = nodeLocalDNSManifest(manifest, kubeProxyMode(data), kubeDNS.Spec.ClusterIP)
+
+	nodeList, err := r.provider.ListNodes(data.Name.ValueString())
+	if err != nil {
+		diagnostics.AddError("Failed to install NodeLocal DNSCache", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	controlPlane, err := nodeutils.BootstrapControlPlaneNode(nodeList)
+	if err != nil {
+		diagnostics.AddError("Failed to install NodeLocal DNSCache", err.Error())
+		return
+	}
+
+	err = newManifestApplier(data).run(ctx, func(ctx context.Context) error {
+		return applyManifestInNode(ctx, controlPlane, manifest)
+	})
+	if err != nil {
+		diagnostics.AddError("Failed to install NodeLocal DNSCache", fmt.Sprintf("Applying the manifest failed: %s", err))
+		return
+	}
+
+	timeout := time.Duration(data.WaitForReady.ValueInt64()) * time.Second
+	if err := waitForDaemonSetReady(ctx, clientset, metav1.NamespaceSystem, nodeLocalDNSDaemonSet, timeout); err != nil {
+		diagnostics.AddError("NodeLocal DNSCache not ready", err.Error())
+	}
+}
+
+// validateNodeLocalDNS rejects install_node_local_dns with kube-proxy modes
+// and IP families the addon does not support: it programs iptables rules
+// that nftables mode does not honour, relies on kube-proxy for the
+// kube-dns-upstream service, and only listens on an IPv4 link-local address.
+func validateNodeLocalDNS(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if !data.InstallNodeLocalDNS.ValueBool() {
+		return
+	}
+
+	if data.Networking != nil && data.Networking.KubeProxyMode.IsUnknown() {
+		return
+	}
+
+	switch mode := kubeProxyMode(data); v1alpha4.ProxyMode(mode) {
+	case v1alpha4.IPTablesProxyMode, v1alpha4.IPVSProxyMode:
+	default:
+		diagnostics.AddAttributeError(
+			path.Root("install_node_local_dns"),
+			"Unsupported kube-proxy Mode",
+			fmt.Sprintf("install_node_local_dns requires networking.kube_proxy_mode iptables or ipvs, got %s.", mode),
+		)
+	}
+
+	if family := configuredIPFamily(data); family == string(v1alpha4.IPv6Family) {
+		diagnostics.AddAttributeError(
+			path.Root("install_node_local_dns"),
+			"Unsupported IP Family",
+			"install_node_local_dns listens on the IPv4 link-local address "+nodeLocalDNSIP+" and does not support ip_family ipv6.",
+		)
+	}
+}
@@ -0,0 +1,820 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/yaml"
+)
+
+// Labels KinD puts on node containers to find a cluster's nodes.
+const (
+	kindClusterLabelKey = "io.x-k8s.kind.cluster"
+	kindRoleLabelKey    = "io.x-k8s.kind.role"
+)
+
+// kindKubeadmConfigPath is where KinD writes the kubeadm config in each node.
+const kindKubeadmConfigPath = "/kind/kubeadm.conf"
+
+// nodeBootRegexp matches the node container log line KinD waits for before
+// running kubeadm in a node.
+var nodeBootRegexp = regexp.MustCompile(`(?m)^Reached target .*Multi-User System.*|detected cgroup v1`)
+
+// nodeEnvPassthrough are node container environment variables KinD derives
+// from the cluster config and host environment. They are copied from an
+// existing worker so new workers see the same proxy and DNS settings.
+var nodeEnvPassthrough = []string{
+	"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy", "KIND_DNS_SEARCH",
+}
+
+var _ planmodifier.List = workerScalingPlanModifier{}
+
+// workerScalingPlanModifier requires replacement for any change to the node
+// list except adding or removing worker nodes at the end of it and changing
+// extra_labels, which Update reconciles in place. Clusters created with
+// stop_before_kubernetes have no API server to do that through, so any change
+// replaces them.
+type workerScalingPlanModifier struct{}
+
+func (m workerScalingPlanModifier) Description(_ context.Context) string {
+	return "Requires replacement unless the change only adds or removes worker nodes at the end of the list or changes extra_labels."
+}
+
+func (m workerScalingPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m workerScalingPlanModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	// Nothing to replace on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	var stopped types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("stop_before_kubernetes"), &stopped)...)
+
+	if stopped.ValueBool() || req.PlanValue.IsUnknown() || !canScaleWorkersInPlace(ctx, req.StateValue.Elements(), req.PlanValue.Elements()) {
+		resp.RequiresReplace = true
+	}
+}
+
+// canScaleWorkersInPlace reports whether planned differs from current only by
+// worker nodes appended or removed at the end, and extra_labels. Added workers must be fully
+// known, must not carry per-node kubeadm patches or kubelet_extra_args, which
+// KinD only applies at creation, and need an existing worker to copy the join configuration from.
+func canScaleWorkersInPlace(ctx context.Context, current, planned []attr.Value) bool {
+	common := min(len(current), len(planned))
+	for i := 0; i < common; i++ {
+		if !equalIgnoringExtraLabels(current[i], planned[i]) {
+			return false
+		}
+	}
+
+	hasWorker := slices.ContainsFunc(current[:common], isWorkerNode)
+
+	for _, node := range current[common:] {
+		if !isWorkerNode(node) {
+			return false
+		}
+	}
+
+	for _, node := range planned[common:] {
+		if !hasWorker || !isWorkerNode(node) {
+			return false
+		}
+
+		value, err := node.ToTerraformValue(ctx)
+		if err != nil || !value.IsFullyKnown() {
+			return false
+		}
+
+		obj, ok := node.(types.Object)
+		if !ok {
+			return false
+		}
+		if patches, ok := obj.Attributes()["kubeadm_config_patches"].(types.List); ok && len(patches.Elements()) > 0 {
+			return false
+		}
+		if patches, ok := obj.Attributes()["kubeadm_config_patches_json6902"].(types.List); ok && len(patches.Elements()) > 0 {
+			return false
+		}
+		if args, ok := obj.Attributes()["kubelet_extra_args"].(types.Map); ok && len(args.Elements()) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isWorkerNode reports whether a node list element has the worker role.
+func isWorkerNode(node attr.Value) bool {
+	obj, ok := node.(types.Object)
+	if !ok {
+		return false
+	}
+
+	role, ok := obj.Attributes()["role"].(types.String)
+	return ok && !role.IsUnknown() && role.ValueString() == string(v1alpha4.WorkerRole)
+}
+
+// containerInspect holds the fields of `docker inspect` output needed to
+// start a worker the way KinD started its siblings.
+type containerInspect struct {
+	ID     string
+	Config struct {
+		Image string
+		Env   []string
+	}
+	HostConfig struct {
+		UsernsMode string
+		Binds      []string
+		Devices    []struct {
+			PathOnHost string
+		}
+		PortBindings map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string
+		}
+	}
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress         string
+			GlobalIPv6Address string
+		}
+	}
+}
+
+// inspectContainer returns the inspect output of a container.
+func inspectContainer(ctx context.Context, runtime, name string) (*containerInspect, error) {
+	out, err := runContainerCommand(ctx, runtime, "inspect", "--type", "container", name)
+	if err != nil {
+		return nil, err
+	}
+
+	var inspected []containerInspect
+	if err := json.Unmarshal([]byte(out), &inspected); err != nil || len(inspected) != 1 {
+		return nil, fmt.Errorf("unexpected inspect output for %s: %v", name, err)
+	}
+
+	return &inspected[0], nil
+}
+
+// containerIDs returns the full IDs of the named containers, in the order
+// given, with a single inspect call.
+func containerIDs(ctx context.Context, runtime string, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	out, err := runContainerCommand(ctx, runtime, append([]string{"inspect", "--type", "container"}, names...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var inspected []containerInspect
+	if err := json.Unmarshal([]byte(out), &inspected); err != nil || len(inspected) != len(names) {
+		return nil, fmt.Errorf("unexpected inspect output for %s: %v", strings.Join(names, ", "), err)
+	}
+
+	ids := make([]string, len(inspected))
+	for i, container := range inspected {
+		ids[i] = container.ID
+	}
+
+	return ids, nil
+}
+
+// scaleWorkers reconciles worker nodes added to or removed from the end of the
+// node list. Removed workers are drained, deleted from the API and their
+// containers removed. Added workers are started with the same container
+// settings KinD uses and joined with a copy of an existing worker's kubeadm
+// configuration.
+func (r *ClusterResource) scaleWorkers(ctx context.Context, state, plan *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	clusterName := plan.Name.ValueString()
+	currentNames := nodeContainerNames(clusterName, r.buildClusterConfig(state).Nodes)
+	planCfg := r.buildClusterConfig(plan)
+	plannedNames := nodeContainerNames(clusterName, planCfg.Nodes)
+
+	var removed []string
+	for _, name := range currentNames {
+		if !slices.Contains(plannedNames, name) {
+			removed = append(removed, name)
+		}
+	}
+
+	var added []int
+	for i, name := range plannedNames {
+		if !slices.Contains(currentNames, name) {
+			added = append(added, i)
+		}
+	}
+
+	if len(removed) == 0 && len(added) == 0 {
+		return
+	}
+
+	if r.runtime != "docker" {
+		diagnostics.AddError(
+			"Failed to scale workers",
+			fmt.Sprintf("Adding or removing workers in place is only supported with the docker runtime, not %s. Recreate the cluster instead.", r.runtime),
+		)
+		return
+	}
+
+	clientset, err := newKubernetesClientset(clientKubeconfig(state))
+	if err != nil {
+		diagnostics.AddError("Failed to scale workers", err.Error())
+		return
+	}
+
+	for _, name := range removed {
+		if err := drainAndDeleteNode(ctx, clientset, name, time.Duration(defaultDrainTimeout)*time.Second); err != nil {
+			diagnostics.AddError("Failed to remove worker", err.Error())
+			return
+		}
+
+		if _, err := runContainerCommand(ctx, r.runtime, "rm", "--force", "--volumes", name); err != nil {
+			diagnostics.AddError("Failed to remove worker", err.Error())
+			return
+		}
+	}
+
+	if len(added) == 0 {
+		return
+	}
+
+	var template string
+	for i, node := range planCfg.Nodes {
+		if node.Role == v1alpha4.WorkerRole && slices.Contains(currentNames, plannedNames[i]) {
+			template = plannedNames[i]
+			break
+		}
+	}
+	if template == "" {
+		diagnostics.AddError("Failed to add worker", "Adding workers in place needs an existing worker to copy its configuration from. Recreate the cluster instead.")
+		return
+	}
+
+	for _, i := range added {
+		if err := r.addWorker(ctx, plan, planCfg, &planCfg.Nodes[i], plannedNames[i], template); err != nil {
+			diagnostics.AddError("Failed to add worker", fmt.Sprintf("Adding worker %s failed: %s", plannedNames[i], err))
+			return
+		}
+
+		if i >= len(plan.Nodes) {
+			continue
+		}
+		if args := listStringValues(plan.Nodes[i].ExtraArgs); len(args) > 0 {
+			updateArgs := append(append([]string{"update"}, args...), plannedNames[i])
+			if _, err := runContainerCommand(ctx, r.runtime, updateArgs...); err != nil {
+				diagnostics.AddError("Failed to apply node extra_args", fmt.Sprintf("Updating node container %s failed: %s", plannedNames[i], err))
+				return
+			}
+		}
+	}
+
+	allNodes, err := r.provider.ListNodes(clusterName)
+	if err != nil {
+		diagnostics.AddError("Failed to add worker", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	var newNodes []nodes.Node
+	for _, node := range allNodes {
+		for _, i := range added {
+			if node.String() != plannedNames[i] {
+				continue
+			}
+			newNodes = append(newNodes, node)
+
+			if i < len(plan.Nodes) {
+				if ulimits := stringMapValue(plan.Nodes[i].Ulimits); len(ulimits) > 0 {
+					if err := setNodeUlimits(ctx, node, ulimits); err != nil {
+						diagnostics.AddError("Failed to apply node ulimits", err.Error())
+						return
+					}
+				}
+			}
+		}
+	}
+
+	if !plan.NodeTimezone.IsNull() {
+		if err := setNodeTimezone(ctx, newNodes, nodeTimezone(plan.NodeTimezone)); err != nil {
+			diagnostics.AddError("Failed to set node timezone", err.Error())
+			return
+		}
+	}
+
+	withImageCacheLock(ctx, plan, diagnostics, func() {
+		r.loadImagesIntoNodes(ctx, newNodes, listStringValues(plan.LoadedImages), diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+
+		loadArchivesIntoNodes(newNodes, listStringValues(plan.ImageArchives), diagnostics)
+	})
+}
+
+// addWorker starts a worker container named name and joins it to the cluster,
+// copying the containerd and kubeadm configuration of the template worker. The
+// container is removed again when any step after starting it fails.
+func (r *ClusterResource) addWorker(ctx context.Context, data *ClusterResourceModel, cfg *v1alpha4.Cluster, node *v1alpha4.Node, name, template string) (err error) {
+	clusterName := data.Name.ValueString()
+
+	tmpl, err := inspectContainer(ctx, r.runtime, template)
+	if err != nil {
+		return err
+	}
+
+	var network string
+	for n := range tmpl.NetworkSettings.Networks {
+		network = n
+	}
+
+	image := tmpl.Config.Image
+	if node.Image != "" {
+		image = node.Image
+	}
+	if !data.NodeImage.IsNull() && data.NodeImage.ValueString() != "" {
+		image = data.NodeImage.ValueString()
+	}
+
+	args := []string{
+		"run", "--detach", "--tty",
+		"--label", fmt.Sprintf("%s=%s", kindClusterLabelKey, clusterName),
+		"--net", network,
+		"--restart=on-failure:1",
+		"--init=false",
+		"--cgroupns=private",
+		"--hostname", name,
+		"--label", fmt.Sprintf("%s=%s", kindRoleLabelKey, v1alpha4.WorkerRole),
+		"--privileged",
+		"--security-opt", "seccomp=unconfined",
+		"--security-opt", "apparmor=unconfined",
+		"--tmpfs", "/tmp",
+		"--tmpfs", "/run",
+		"--volume", "/var",
+		"--volume", "/lib/modules:/lib/modules:ro",
+		"-e", "KIND_EXPERIMENTAL_CONTAINERD_SNAPSHOTTER",
+	}
+
+	if cfg.Networking.IPFamily == v1alpha4.IPv6Family || cfg.Networking.IPFamily == v1alpha4.DualStackFamily {
+		args = append(args, "--sysctl=net.ipv6.conf.all.disable_ipv6=0", "--sysctl=net.ipv6.conf.all.forwarding=1")
+	}
+
+	for _, env := range tmpl.Config.Env {
+		key, _, _ := strings.Cut(env, "=")
+		if slices.Contains(nodeEnvPassthrough, key) {
+			args = append(args, "-e", env)
+		}
+	}
+
+	if tmpl.HostConfig.UsernsMode == "host" {
+		args = append(args, "--userns=host")
+	}
+	if slices.Contains(tmpl.HostConfig.Binds, "/dev/mapper:/dev/mapper") {
+		args = append(args, "--volume", "/dev/mapper:/dev/mapper")
+	}
+	if slices.ContainsFunc(tmpl.HostConfig.Devices, func(d struct{ PathOnHost string }) bool { return d.PathOnHost == "/dev/fuse" }) {
+		args = append(args, "--device", "/dev/fuse")
+	}
+
+	mounts := node.ExtraMounts
+	if data.EnableImageCachePassthrough.ValueBool() {
+		dir, err := imageCacheHostDir()
+		if err != nil {
+			return err
+		}
+		mounts = append(mounts, v1alpha4.Mount{HostPath: dir, ContainerPath: nodeContentStoreDir})
+	}
+	mountArgs, err := mountBindingArgs(mounts)
+	if err != nil {
+		return err
+	}
+	args = append(args, mountArgs...)
+	args = append(args, portMappingArgs(cfg.Networking.IPFamily, node.ExtraPortMappings)...)
+	args = append(args, "--name", name, image)
+
+	if _, err := runContainerCommand(ctx, r.runtime, args...); err != nil {
+		return err
+	}
+
+	// A worker that never joined would otherwise be left running, and would
+	// make the next apply fail on the container name.
+	defer func() {
+		if err == nil {
+			return
+		}
+		if _, rmErr := runContainerCommand(context.WithoutCancel(ctx), r.runtime, "rm", "--force", "--volumes", name); rmErr != nil {
+			err = fmt.Errorf("%w; removing container %s also failed: %s", err, name, rmErr)
+		}
+	}()
+
+	if err := waitForNodeBoot(ctx, r.runtime, name); err != nil {
+		return err
+	}
+
+	allNodes, err := r.provider.ListNodes(clusterName)
+	if err != nil {
+		return fmt.Errorf("could not list cluster nodes: %w", err)
+	}
+
+	var templateNode, newNode nodes.Node
+	for _, n := range allNodes {
+		switch n.String() {
+		case template:
+			templateNode = n
+		case name:
+			newNode = n
+		}
+	}
+	if templateNode == nil || newNode == nil {
+		return fmt.Errorf("could not find nodes %s and %s", template, name)
+	}
+
+	// Containerd config patches are applied by KinD while provisioning, so the
+	// rendered config is copied from the template.
+	containerdConfig, err := readNodeFile(ctx, templateNode, "/etc/containerd/config.toml")
+	if err != nil {
+		return err
+	}
+	if err := nodeutils.WriteFile(newNode, "/etc/containerd/config.toml", containerdConfig); err != nil {
+		return err
+	}
+	if err := newNode.CommandContext(ctx, "systemctl", "restart", "containerd").Run(); err != nil {
+		return fmt.Errorf("restarting containerd failed: %w", err)
+	}
+
+	if certs := stringMapValue(data.RegistryCerts); len(certs) > 0 {
+		if err := installRegistryCerts(ctx, []nodes.Node{newNode}, certs); err != nil {
+			return err
+		}
+	}
+
+	if mirrors := registryMirrors(data); len(mirrors) > 0 {
+		if err := installRegistryMirrors(ctx, []nodes.Node{newNode}, mirrors, stringMapValue(data.RegistryCerts)); err != nil {
+			return err
+		}
+	}
+
+	if data.ClusterCABundle != nil {
+		if err := installNodeCABundle(ctx, []nodes.Node{newNode}, data.ClusterCABundle.PEM.ValueString()); err != nil {
+			return err
+		}
+	}
+
+	newInspect, err := inspectContainer(ctx, r.runtime, name)
+	if err != nil {
+		return err
+	}
+
+	templateConfig, err := readNodeFile(ctx, templateNode, kindKubeadmConfigPath)
+	if err != nil {
+		return err
+	}
+
+	joinConfig, token, err := rewriteJoinConfig(templateConfig, joinNodeSettings{
+		name:       name,
+		providerID: fmt.Sprintf("kind://docker/%s/%s", clusterName, name),
+		labels:     node.Labels,
+		addresses: map[string]string{
+			tmpl.NetworkSettings.Networks[network].IPAddress:         newInspect.NetworkSettings.Networks[network].IPAddress,
+			tmpl.NetworkSettings.Networks[network].GlobalIPv6Address: newInspect.NetworkSettings.Networks[network].GlobalIPv6Address,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := nodeutils.WriteFile(newNode, kindKubeadmConfigPath, joinConfig); err != nil {
+		return err
+	}
+
+	// KinD's bootstrap token may have expired since the cluster was created.
+	if token != "" {
+		controlPlane, err := nodeutils.BootstrapControlPlaneNode(allNodes)
+		if err != nil {
+			return err
+		}
+
+		var stderr bytes.Buffer
+		cmd := controlPlane.CommandContext(ctx, "kubeadm", "token", "create", token, "--ttl", "1h")
+		cmd.SetStderr(&stderr)
+		if err := cmd.Run(); err != nil && !strings.Contains(stderr.String(), "already exists") {
+			return fmt.Errorf("creating the bootstrap token failed: %w: %s", err, stderr.String())
+		}
+	}
+
+	var output bytes.Buffer
+	cmd := newNode.CommandContext(ctx, "kubeadm", "join", "--config", kindKubeadmConfigPath, "--skip-phases=preflight", "--v=6")
+	cmd.SetStdout(&output)
+	cmd.SetStderr(&output)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("kubeadm join failed: %w: %s", err, lastLines(output.String(), 20))
+	}
+
+	return nil
+}
+
+// waitForNodeBoot waits until the node container's init system is up, the
+// same signal KinD waits for before running kubeadm.
+func waitForNodeBoot(ctx context.Context, runtime, name string) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		logs, err := runContainerCommand(ctx, runtime, "logs", name)
+		if err == nil && nodeBootRegexp.MatchString(logs) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("node container %s did not finish booting: %w", name, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// readNodeFile returns the content of a file inside a node.
+func readNodeFile(ctx context.Context, node nodes.Node, path string) (string, error) {
+	var out bytes.Buffer
+	cmd := node.CommandContext(ctx, "cat", path)
+	cmd.SetStdout(&out)
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("reading %s from node %s failed: %w", path, node.String(), err)
+	}
+
+	return out.String(), nil
+}
+
+// joinNodeSettings are the node-specific values of a kubeadm JoinConfiguration.
+type joinNodeSettings struct {
+	name       string
+	providerID string
+	labels     map[string]string
+	// addresses maps template node IPs to the new node's IPs.
+	addresses map[string]string
+}
+
+// rewriteJoinConfig adapts a worker's KinD kubeadm config to another worker:
+// the node name, provider ID, node IPs and labels of the JoinConfiguration are
+// replaced, other documents are kept. It also returns the bootstrap token the
+// JoinConfiguration uses.
+func rewriteJoinConfig(config string, settings joinNodeSettings) (string, string, error) {
+	docs := strings.Split(config, "\n---\n")
+	token := ""
+
+	for i, doc := range docs {
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return "", "", fmt.Errorf("failed to parse kubeadm config: %w", err)
+		}
+		if obj["kind"] != "JoinConfiguration" {
+			continue
+		}
+
+		if discovery, ok := obj["discovery"].(map[string]interface{}); ok {
+			if bootstrapToken, ok := discovery["bootstrapToken"].(map[string]interface{}); ok {
+				token, _ = bootstrapToken["token"].(string)
+			}
+		}
+
+		registration, _ := obj["nodeRegistration"].(map[string]interface{})
+		if registration == nil {
+			registration = map[string]interface{}{}
+			obj["nodeRegistration"] = registration
+		}
+		registration["name"] = settings.name
+
+		labels := make([]string, 0, len(settings.labels))
+		for k, v := range settings.labels {
+			labels = append(labels, k+"="+v)
+		}
+		slices.Sort(labels)
+
+		setArg := func(args map[string]string) {
+			args["provider-id"] = settings.providerID
+			args["node-labels"] = strings.Join(labels, ",")
+			if ips, ok := args["node-ip"]; ok {
+				parts := strings.Split(ips, ",")
+				for j, ip := range parts {
+					if replacement, ok := settings.addresses[ip]; ok && ip != "" {
+						parts[j] = replacement
+					}
+				}
+				args["node-ip"] = strings.Join(parts, ",")
+			}
+			if args["node-labels"] == "" {
+				delete(args, "node-labels")
+			}
+		}
+
+		switch extraArgs := registration["kubeletExtraArgs"].(type) {
+		case []interface{}:
+			// kubeadm v1beta4 lists arguments as name/value pairs.
+			args := map[string]string{}
+			var order []string
+			for _, item := range extraArgs {
+				if arg, ok := item.(map[string]interface{}); ok {
+					argName, _ := arg["name"].(string)
+					argValue, _ := arg["value"].(string)
+					args[argName] = argValue
+					order = append(order, argName)
+				}
+			}
+			setArg(args)
+			list := make([]interface{}, 0, len(args))
+			for _, argName := range append(order, "provider-id", "node-labels") {
+				if value, ok := args[argName]; ok {
+					list = append(list, map[string]interface{}{"name": argName, "value": value})
+					delete(args, argName)
+				}
+			}
+			registration["kubeletExtraArgs"] = list
+		default:
+			args := map[string]string{}
+			if m, ok := extraArgs.(map[string]interface{}); ok {
+				for k, v := range m {
+					args[k], _ = v.(string)
+				}
+			}
+			setArg(args)
+			registration["kubeletExtraArgs"] = args
+		}
+
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to render kubeadm config: %w", err)
+		}
+		docs[i] = string(out)
+	}
+
+	return strings.Join(docs, "\n---\n"), token, nil
+}
+
+// mountBindingArgs converts extra mounts to container run arguments the way
+// KinD's docker provider does.
+func mountBindingArgs(mounts []v1alpha4.Mount) ([]string, error) {
+	args := make([]string, 0, len(mounts))
+	for _, m := range mounts {
+		hostPath, err := filepath.Abs(m.HostPath)
+		if err != nil {
+			return nil, err
+		}
+
+		bind := fmt.Sprintf("%s:%s", hostPath, m.ContainerPath)
+		var attrs []string
+		if m.Readonly {
+			attrs = append(attrs, "ro")
+		}
+		if m.SelinuxRelabel {
+			attrs = append(attrs, "Z")
+		}
+		switch m.Propagation {
+		case v1alpha4.MountPropagationBidirectional:
+			attrs = append(attrs, "rshared")
+		case v1alpha4.MountPropagationHostToContainer:
+			attrs = append(attrs, "rslave")
+		}
+		if len(attrs) > 0 {
+			bind = fmt.Sprintf("%s:%s", bind, strings.Join(attrs, ","))
+		}
+		args = append(args, "--volume="+bind)
+	}
+
+	return args, nil
+}
+
+// portMappingArgs converts extra port mappings to container run arguments the
+// way KinD's docker provider does. A host port of 0 lets the runtime pick one.
+func portMappingArgs(ipFamily v1alpha4.ClusterIPFamily, mappings []v1alpha4.PortMapping) []string {
+	args := make([]string, 0, len(mappings))
+	for _, pm := range mappings {
+		listenAddress := pm.ListenAddress
+		if listenAddress == "" {
+			listenAddress = "0.0.0.0"
+			if ipFamily == v1alpha4.IPv6Family {
+				listenAddress = "::"
+			}
+		}
+
+		protocol := strings.ToLower(string(pm.Protocol))
+		if protocol == "" {
+			protocol = "tcp"
+		}
+
+		hostPort := max(pm.HostPort, 0)
+		args = append(args, fmt.Sprintf("--publish=%s:%d/%s", net.JoinHostPort(listenAddress, fmt.Sprint(hostPort)), pm.ContainerPort, protocol))
+	}
+
+	return args
+}
+
+// drainAndDeleteNode cordons a node, evicts its pods except DaemonSet and
+// static pods, and deletes the Node object.
+func drainAndDeleteNode(ctx context.Context, clientset kubernetes.Interface, name string, timeout time.Duration) error {
+	node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %w", name, err)
+	}
+
+	if !node.Spec.Unschedulable {
+		node.Spec.Unschedulable = true
+		if _, err := clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to cordon node %s: %w", name, err)
+		}
+	}
+
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pods on node %s: %w", name, err)
+	}
+
+	var evicted []corev1.Pod
+	for _, pod := range pods.Items {
+		if isDaemonSetOrStaticPod(&pod) {
+			continue
+		}
+
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		}
+		if err := clientset.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to evict pod %s/%s from node %s: %w", pod.Namespace, pod.Name, name, err)
+		}
+		evicted = append(evicted, pod)
+	}
+
+	// Evicted pods run their shutdown on the node, so it is only deleted once
+	// they are gone.
+	terminating, err := waitForPodsDeleted(ctx, clientset, evicted, timeout)
+	if err != nil {
+		return err
+	}
+	if len(terminating) > 0 {
+		return fmt.Errorf("pods on node %s did not terminate within %s: %s", name, timeout, strings.Join(terminating, ", "))
+	}
+
+	if err := clientset.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete node %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// isDaemonSetOrStaticPod reports whether a pod is managed by a DaemonSet or
+// the kubelet, which drains leave in place.
+func isDaemonSetOrStaticPod(pod *corev1.Pod) bool {
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return true
+	}
+
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lastLines returns the last n lines of s.
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return strings.Join(lines, "\n")
+}
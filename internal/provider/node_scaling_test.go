@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+var testNodeAttrTypes = map[string]attr.Type{
+	"role":               types.StringType,
+	"extra_labels":       types.MapType{ElemType: types.StringType},
+	"kubelet_extra_args": types.MapType{ElemType: types.StringType},
+}
+
+func testNode(role string, labels, kubeletArgs map[string]string) attr.Value {
+	return types.ObjectValueMust(testNodeAttrTypes, map[string]attr.Value{
+		"role":               types.StringValue(role),
+		"extra_labels":       testStringMap(labels),
+		"kubelet_extra_args": testStringMap(kubeletArgs),
+	})
+}
+
+func testStringMap(m map[string]string) types.Map {
+	if m == nil {
+		return types.MapNull(types.StringType)
+	}
+
+	return types.MapValueMust(types.StringType, mapStringValues(m))
+}
+
+func mapStringValues(m map[string]string) map[string]attr.Value {
+	values := make(map[string]attr.Value, len(m))
+	for k, v := range m {
+		values[k] = types.StringValue(v)
+	}
+
+	return values
+}
+
+func TestCanScaleWorkersInPlace(t *testing.T) {
+	controlPlane := testNode("control-plane", nil, nil)
+	worker := testNode("worker", nil, nil)
+
+	tests := []struct {
+		name    string
+		current []attr.Value
+		planned []attr.Value
+		want    bool
+	}{
+		{"add worker", []attr.Value{controlPlane, worker}, []attr.Value{controlPlane, worker, worker}, true},
+		{"remove worker", []attr.Value{controlPlane, worker, worker}, []attr.Value{controlPlane, worker}, true},
+		{"relabel worker", []attr.Value{controlPlane, worker}, []attr.Value{controlPlane, testNode("worker", map[string]string{"tier": "web"}, nil)}, true},
+		{"add control plane", []attr.Value{controlPlane, worker}, []attr.Value{controlPlane, worker, controlPlane}, false},
+		{"remove control plane", []attr.Value{controlPlane, controlPlane}, []attr.Value{controlPlane}, false},
+		{"no worker to copy", []attr.Value{controlPlane}, []attr.Value{controlPlane, worker}, false},
+		{"added worker with kubelet_extra_args", []attr.Value{controlPlane, worker}, []attr.Value{controlPlane, worker, testNode("worker", nil, map[string]string{"v": "2"})}, false},
+		{"changed kubelet_extra_args", []attr.Value{controlPlane, worker}, []attr.Value{controlPlane, testNode("worker", nil, map[string]string{"v": "2"})}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canScaleWorkersInPlace(context.Background(), tt.current, tt.planned); got != tt.want {
+				t.Errorf("canScaleWorkersInPlace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeContainerNamesAfterScaling(t *testing.T) {
+	nodes := func(workers int) []v1alpha4.Node {
+		list := []v1alpha4.Node{{Role: v1alpha4.ControlPlaneRole}}
+		for range workers {
+			list = append(list, v1alpha4.Node{Role: v1alpha4.WorkerRole})
+		}
+		return list
+	}
+
+	current := nodeContainerNames("dev", nodes(2))
+	planned := nodeContainerNames("dev", nodes(3))
+
+	// Scaling up keeps every existing container and adds only the new one, so
+	// state converges on the names KinD would have chosen for the larger cluster.
+	want := []string{"dev-control-plane", "dev-worker", "dev-worker2", "dev-worker3"}
+	if !slices.Equal(planned, want) {
+		t.Fatalf("planned names = %v, want %v", planned, want)
+	}
+	if !slices.Equal(planned[:len(current)], current) {
+		t.Errorf("scaling renamed existing nodes: %v -> %v", current, planned)
+	}
+}
+
+// newDrainClientset returns a fake clientset with a node running one pod.
+// Evictions delete the pod when evictionDeletes is set and are accepted but
+// leave it running otherwise, like a pod with a long grace period.
+func newDrainClientset(evictionDeletes bool) *fake.Clientset {
+	clientset := fake.NewClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "dev-worker2"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "web-1"},
+			Spec:       corev1.PodSpec{NodeName: "dev-worker2"},
+		},
+	)
+
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		if evictionDeletes {
+			name := action.(k8stesting.CreateAction).GetObject().(metav1.Object).GetName()
+			if err := clientset.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("pods"), action.GetNamespace(), name); err != nil {
+				return true, nil, err
+			}
+		}
+		return true, nil, nil
+	})
+
+	return clientset
+}
+
+func TestDrainAndDeleteNode(t *testing.T) {
+	ctx := context.Background()
+	clientset := newDrainClientset(true)
+
+	if err := drainAndDeleteNode(ctx, clientset, "dev-worker2", time.Minute); err != nil {
+		t.Fatalf("drainAndDeleteNode() error = %v", err)
+	}
+
+	if _, err := clientset.CoreV1().Pods("default").Get(ctx, "web", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("pod still exists after drain: %v", err)
+	}
+	if _, err := clientset.CoreV1().Nodes().Get(ctx, "dev-worker2", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("node still exists after drain: %v", err)
+	}
+}
+
+func TestDrainAndDeleteNodeWaitsForTermination(t *testing.T) {
+	ctx := context.Background()
+	clientset := newDrainClientset(false)
+
+	err := drainAndDeleteNode(ctx, clientset, "dev-worker2", 50*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "default/web") {
+		t.Fatalf("drainAndDeleteNode() error = %v, want a timeout naming default/web", err)
+	}
+
+	node, err := clientset.CoreV1().Nodes().Get(ctx, "dev-worker2", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("node was deleted while its pods were still terminating: %v", err)
+	}
+	if !node.Spec.Unschedulable {
+		t.Error("node was not cordoned")
+	}
+}
+
+func TestDrainAndDeleteNodeMissing(t *testing.T) {
+	if err := drainAndDeleteNode(context.Background(), fake.NewClientset(), "dev-worker9", time.Minute); err != nil {
+		t.Errorf("drainAndDeleteNode() error = %v, want nil for a node that is already gone", err)
+	}
+}
+
+// testWorkerInspect is inspect output of a worker container.
+const testWorkerInspect = `[{"Config":{"Image":"kindest/node:v1.35.0"},"NetworkSettings":{"Networks":{"kind":{}}}}]`
+
+// fakeRuntime writes a container runtime stand-in that records its arguments
+// and answers inspect with the given output. Other commands print nothing, so
+// node logs never show the boot message.
+func fakeRuntime(t *testing.T, inspect string) (string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	log := filepath.Join(dir, "calls")
+	script := filepath.Join(dir, "docker")
+	content := `#!/bin/sh
+echo "$*" >> "` + log + `"
+case "$1" in
+inspect) cat "` + filepath.Join(dir, "inspect.json") + `" ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "inspect.json"), []byte(inspect), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(script, []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	return script, log
+}
+
+func TestAddWorkerRemovesContainerOnFailure(t *testing.T) {
+	runtime, log := fakeRuntime(t, testWorkerInspect)
+	r := &ClusterResource{runtime: runtime}
+	data := &ClusterResourceModel{
+		Name:      types.StringValue("dev"),
+		NodeImage: types.StringNull(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := r.addWorker(ctx, data, &v1alpha4.Cluster{}, &v1alpha4.Node{Role: v1alpha4.WorkerRole}, "dev-worker2", "dev-worker")
+	if err == nil {
+		t.Fatal("addWorker() succeeded without the node booting")
+	}
+
+	calls, readErr := os.ReadFile(log)
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	if !strings.Contains(string(calls), "rm --force --volumes dev-worker2") {
+		t.Errorf("container was not removed after the failure, runtime calls:\n%s", calls)
+	}
+}
+
+func TestAddWorkerKeepsNothingWhenRunFails(t *testing.T) {
+	runtime, log := fakeRuntime(t, testWorkerInspect)
+	r := &ClusterResource{runtime: runtime}
+
+	// The template cannot be inspected, so no container is started and none
+	// may be removed: a container of that name would belong to someone else.
+	if err := os.WriteFile(runtime, []byte("#!/bin/sh\necho \"$*\" >> \""+log+"\"\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	data := &ClusterResourceModel{Name: types.StringValue("dev"), NodeImage: types.StringNull()}
+	if err := r.addWorker(context.Background(), data, &v1alpha4.Cluster{}, &v1alpha4.Node{Role: v1alpha4.WorkerRole}, "dev-worker2", "dev-worker"); err == nil {
+		t.Fatal("addWorker() succeeded with a failing runtime")
+	}
+
+	calls, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(calls), "rm ") {
+		t.Errorf("a container was removed although none was started, runtime calls:\n%s", calls)
+	}
+}
+
+func TestContainerIDs(t *testing.T) {
+	runtime, log := fakeRuntime(t, `[{"Id":"aaa111"},{"Id":"bbb222"}]`)
+
+	ids, err := containerIDs(context.Background(), runtime, []string{"dev-control-plane", "dev-worker"})
+	if err != nil {
+		t.Fatalf("containerIDs() error = %v", err)
+	}
+	if want := []string{"aaa111", "bbb222"}; !slices.Equal(ids, want) {
+		t.Errorf("containerIDs() = %v, want %v", ids, want)
+	}
+
+	calls, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(calls)); got != "inspect --type container dev-control-plane dev-worker" {
+		t.Errorf("runtime calls = %q, want a single inspect of both containers", got)
+	}
+
+	if _, err := containerIDs(context.Background(), runtime, []string{"dev-control-plane"}); err == nil {
+		t.Error("containerIDs() accepted inspect output for a different number of containers")
+	}
+}
+
+func testAccScaleConfig(name string, workers int) string {
+	config := fmt.Sprintf("resource \"kind_cluster\" \"test\" {\n  name = %q\n\n  node {\n    role = \"control-plane\"\n  }\n", name)
+	for range workers {
+		config += "\n  node {\n    role = \"worker\"\n  }\n"
+	}
+
+	return config + "}\n"
+}
+
+// TestAccClusterResourceScaleWorkers scales a cluster from one worker to two
+// and back in place, checking after each step that the state matches the
+// nodes, a fresh plan is empty and the kubeconfig still reaches every node.
+func TestAccClusterResourceScaleWorkers(t *testing.T) {
+	name := "tf-acc-scale"
+	address := "kind_cluster.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccScaleConfig(name, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(address, "node_names.#", "2"),
+					testAccCheckReadyNodes(address, 2),
+				),
+			},
+			{
+				Config: testAccScaleConfig(name, 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply:             []plancheck.PlanCheck{plancheck.ExpectResourceAction(address, plancheck.ResourceActionUpdate)},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(address, "node.#", "3"),
+					resource.TestCheckResourceAttr(address, "node_names.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(address, "node_names.*", map[string]string{
+						"container_name":       name + "-worker2",
+						"role":                 "worker",
+						"kubernetes_node_name": name + "-worker2",
+					}),
+					testAccCheckReadyNodes(address, 3),
+				),
+			},
+			{
+				Config:   testAccScaleConfig(name, 2),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccScaleConfig(name, 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply:             []plancheck.PlanCheck{plancheck.ExpectResourceAction(address, plancheck.ResourceActionUpdate)},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(address, "node.#", "2"),
+					resource.TestCheckResourceAttr(address, "node_names.#", "2"),
+					resource.TestCheckResourceAttr(address, "node_names.1.container_name", name+"-worker"),
+					testAccCheckReadyNodes(address, 2),
+				),
+			},
+			{
+				Config:   testAccScaleConfig(name, 1),
+				PlanOnly: true,
+			},
+		},
+	})
+}
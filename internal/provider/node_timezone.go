@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+
+	// Embeds the IANA database so node_timezone validates the same way on
+	// hosts without zoneinfo files.
+	_ "time/tzdata"
+)
+
+// defaultNodeTimezone is the timezone KinD node images ship with. It is
+// restored when node_timezone is removed.
+const defaultNodeTimezone = "Etc/UTC"
+
+// zoneinfoDir holds the IANA zone files on the host and in node images.
+const zoneinfoDir = "/usr/share/zoneinfo"
+
+// nodeTimezone returns the configured node timezone or the node image default.
+func nodeTimezone(value types.String) string {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return defaultNodeTimezone
+	}
+
+	return value.ValueString()
+}
+
+// applyNodeTimezone sets the timezone of every node of the cluster.
+func applyNodeTimezone(ctx context.Context, provider *cluster.Provider, clusterName, timezone string, diagnostics *diag.Diagnostics) {
+	nodeList, err := provider.ListNodes(clusterName)
+	if err != nil {
+		diagnostics.AddError("Failed to set node timezone", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	if err := setNodeTimezone(ctx, nodeList, timezone); err != nil {
+		diagnostics.AddError("Failed to set node timezone", err.Error())
+	}
+}
+
+// setNodeTimezone points /etc/localtime of the given nodes at the zone file
+// of timezone and records it in /etc/timezone. Node images without the zone
+// file get a copy of the host's. Only processes started afterwards in the
+// node pick up the change; pods keep the timezone of their own images.
+func setNodeTimezone(ctx context.Context, nodeList []nodes.Node, timezone string) error {
+	zoneFile := path.Join(zoneinfoDir, timezone)
+
+	for _, node := range nodeList {
+		if node.CommandContext(ctx, "test", "-f", zoneFile).Run() == nil {
+			if err := node.CommandContext(ctx, "ln", "-sf", zoneFile, "/etc/localtime").Run(); err != nil {
+				return fmt.Errorf("linking %s on node %s failed: %w", zoneFile, node.String(), err)
+			}
+		} else {
+			data, err := os.ReadFile(zoneFile)
+			if err != nil {
+				return fmt.Errorf("node %s has no zone file for %s and it could not be read on the host: %w", node.String(), timezone, err)
+			}
+
+			cmd := node.CommandContext(ctx, "sh", "-c", "rm -f /etc/localtime && cat > /etc/localtime")
+			cmd.SetStdin(bytes.NewReader(data))
+			if err := cmd.Run(); err != nil {
+				return fmt.Errorf("writing /etc/localtime on node %s failed: %w", node.String(), err)
+			}
+		}
+
+		cmd := node.CommandContext(ctx, "sh", "-c", "cat > /etc/timezone")
+		cmd.SetStdin(strings.NewReader(timezone + "\n"))
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("writing /etc/timezone on node %s failed: %w", node.String(), err)
+		}
+	}
+
+	return nil
+}
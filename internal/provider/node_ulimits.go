@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+)
+
+// nodeUlimitDirectives maps the ulimits supported in node.ulimits to the
+// systemd unit directive setting them.
+var nodeUlimitDirectives = map[string]string{
+	"nofile": "LimitNOFILE",
+	"nproc":  "LimitNPROC",
+}
+
+// nodeUlimitUnits are the node services that run Kubernetes workloads and get
+// the configured ulimits through a systemd drop-in.
+var nodeUlimitUnits = []string{"containerd", "kubelet"}
+
+// parseUlimit splits a soft:hard ulimit. Each side is a non-negative number
+// or "unlimited", and the soft limit must not exceed the hard one.
+func parseUlimit(value string) (string, string, error) {
+	soft, hard, ok := strings.Cut(value, ":")
+	if !ok {
+		return "", "", fmt.Errorf("must be in the form soft:hard")
+	}
+
+	limits := make([]uint64, 2)
+	for i, limit := range []string{soft, hard} {
+		if limit == "unlimited" {
+			limits[i] = ^uint64(0)
+			continue
+		}
+
+		n, err := strconv.ParseUint(limit, 10, 64)
+		if err != nil {
+			return "", "", fmt.Errorf("%q is not a number or unlimited", limit)
+		}
+		limits[i] = n
+	}
+
+	if limits[0] > limits[1] {
+		return "", "", fmt.Errorf("soft limit %s exceeds hard limit %s", soft, hard)
+	}
+
+	return soft, hard, nil
+}
+
+// applyNodeUlimits raises the ulimits of the node containers that configure
+// node.ulimits. Container runtimes cannot change the ulimits of a running
+// container, so they are applied inside the node instead.
+func applyNodeUlimits(ctx context.Context, provider *cluster.Provider, clusterName string, nodeModels []NodeModel, cfgNodes []v1alpha4.Node, diagnostics *diag.Diagnostics) {
+	containerNames := nodeContainerNames(clusterName, cfgNodes)
+
+	var nodeList []nodes.Node
+	for i, node := range nodeModels {
+		ulimits := stringMapValue(node.Ulimits)
+		if len(ulimits) == 0 {
+			continue
+		}
+
+		if nodeList == nil {
+			var err error
+			nodeList, err = provider.ListNodes(clusterName)
+			if err != nil {
+				diagnostics.AddError("Failed to apply node ulimits", fmt.Sprintf("Could not list cluster nodes: %s", err))
+				return
+			}
+		}
+
+		for _, n := range nodeList {
+			if n.String() != containerNames[i] {
+				continue
+			}
+
+			if err := setNodeUlimits(ctx, n, ulimits); err != nil {
+				diagnostics.AddError("Failed to apply node ulimits", err.Error())
+				return
+			}
+		}
+	}
+}
+
+// setNodeUlimits sets the ulimits of the node's init process, so processes it
+// starts inherit them, and of the containerd and kubelet services, which are
+// restarted to pick them up.
+func setNodeUlimits(ctx context.Context, node nodes.Node, ulimits map[string]string) error {
+	names := make([]string, 0, len(ulimits))
+	for name := range ulimits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	prlimitArgs := []string{"--pid", "1"}
+	dropIn := "[Service]\n"
+	for _, name := range names {
+		soft, hard, err := parseUlimit(ulimits[name])
+		if err != nil {
+			return fmt.Errorf("invalid %s ulimit for node %s: %w", name, node.String(), err)
+		}
+
+		prlimitArgs = append(prlimitArgs, fmt.Sprintf("--%s=%s:%s", name, soft, hard))
+		dropIn += fmt.Sprintf("%s=%s:%s\n", nodeUlimitDirectives[name], strings.ReplaceAll(soft, "unlimited", "infinity"), strings.ReplaceAll(hard, "unlimited", "infinity"))
+	}
+
+	if err := node.CommandContext(ctx, "prlimit", prlimitArgs...).Run(); err != nil {
+		return fmt.Errorf("setting ulimits on node %s failed: %w", node.String(), err)
+	}
+
+	for _, unit := range nodeUlimitUnits {
+		dir := fmt.Sprintf("/etc/systemd/system/%s.service.d", unit)
+		cmd := node.CommandContext(ctx, "sh", "-c", fmt.Sprintf("mkdir -p %q && cat > %q", dir, dir+"/20-ulimits.conf"))
+		cmd.SetStdin(strings.NewReader(dropIn))
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("writing the %s ulimits on node %s failed: %w", unit, node.String(), err)
+		}
+	}
+
+	if err := node.CommandContext(ctx, "systemctl", "daemon-reload").Run(); err != nil {
+		return fmt.Errorf("reloading systemd on node %s failed: %w", node.String(), err)
+	}
+
+	restartArgs := append([]string{"restart"}, nodeUlimitUnits...)
+	if err := node.CommandContext(ctx, "systemctl", restartArgs...).Run(); err != nil {
+		return fmt.Errorf("restarting %s on node %s failed: %w", strings.Join(nodeUlimitUnits, " and "), node.String(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Patch types accepted by validatePatch.
+const (
+	patchTypeMerge          = "merge"
+	patchTypeJSON6902       = "json6902"
+	patchTypeContainerdTOML = "containerd-toml"
+)
+
+var patchTypes = []string{patchTypeMerge, patchTypeJSON6902, patchTypeContainerdTOML}
+
+// validatePatch parses a patch the same way KinD will when creating the
+// cluster and returns the parse error, if any.
+func validatePatch(patchType, patch string) error {
+	switch patchType {
+	case patchTypeMerge:
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal([]byte(patch), &doc); err != nil {
+			return fmt.Errorf("invalid merge patch: %w", err)
+		}
+		if doc == nil {
+			return fmt.Errorf("invalid merge patch: patch must be a YAML mapping")
+		}
+	case patchTypeJSON6902:
+		patchJSON, err := yaml.YAMLToJSON([]byte(patch))
+		if err != nil {
+			return fmt.Errorf("invalid JSON 6902 patch: %w", err)
+		}
+		if _, err := jsonpatch.DecodePatch(patchJSON); err != nil {
+			return fmt.Errorf("invalid JSON 6902 patch: %w", err)
+		}
+	case patchTypeContainerdTOML:
+		var doc map[string]interface{}
+		if _, err := toml.Decode(patch, &doc); err != nil {
+			return fmt.Errorf("invalid containerd TOML patch: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown patch type %q", patchType)
+	}
+
+	return nil
+}
+
+// validatePatchList reports an attribute error for every element of a patch
+// list attribute that fails to parse.
+func validatePatchList(attrPath path.Path, patchType string, patches types.List, diagnostics *diag.Diagnostics) {
+	if patches.IsNull() || patches.IsUnknown() {
+		return
+	}
+
+	for i, elem := range patches.Elements() {
+		strVal, ok := elem.(types.String)
+		if !ok || strVal.IsNull() || strVal.IsUnknown() {
+			continue
+		}
+
+		if err := validatePatch(patchType, strVal.ValueString()); err != nil {
+			diagnostics.AddAttributeError(attrPath.AtListIndex(i), "Invalid Patch", err.Error())
+		}
+	}
+}
+
+// validateConfigPatches checks that every patch in the configuration parses.
+func validateConfigPatches(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	validatePatchList(path.Root("kubeadm_config_patches"), patchTypeMerge, data.KubeadmConfigPatches, diagnostics)
+	validatePatchList(path.Root("containerd_config_patches"), patchTypeContainerdTOML, data.ContainerdConfigPatches, diagnostics)
+	validatePatchList(path.Root("containerd_config_patches_json6902"), patchTypeJSON6902, data.ContainerdConfigPatchesJSON6902, diagnostics)
+
+	for i, p := range data.KubeadmConfigPatchesJSON6902 {
+		if p.Patch.IsNull() || p.Patch.IsUnknown() {
+			continue
+		}
+		if err := validatePatch(patchTypeJSON6902, p.Patch.ValueString()); err != nil {
+			diagnostics.AddAttributeError(path.Root("kubeadm_config_patches_json6902").AtListIndex(i).AtName("patch"), "Invalid Patch", err.Error())
+		}
+	}
+
+	for i, node := range data.Nodes {
+		nodePath := path.Root("node").AtListIndex(i)
+		validatePatchList(nodePath.AtName("kubeadm_config_patches"), patchTypeMerge, node.KubeadmConfigPatches, diagnostics)
+
+		for j, p := range node.KubeadmConfigPatchesJSON6902 {
+			if p.Patch.IsNull() || p.Patch.IsUnknown() {
+				continue
+			}
+			if err := validatePatch(patchTypeJSON6902, p.Patch.ValueString()); err != nil {
+				diagnostics.AddAttributeError(nodePath.AtName("kubeadm_config_patches_json6902").AtListIndex(j).AtName("patch"), "Invalid Patch", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &PatchValidationDataSource{}
+
+type PatchValidationDataSource struct{}
+
+func NewPatchValidationDataSource() datasource.DataSource {
+	return &PatchValidationDataSource{}
+}
+
+func (d *PatchValidationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_patch_validation"
+}
+
+func (d *PatchValidationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Validate a kubeadm or containerd config patch without creating a cluster. Uses the same parsers as the kind_cluster resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier.",
+				Computed:    true,
+			},
+			"patch": schema.StringAttribute{
+				Description: "The patch content to validate.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Patch type: merge (kubeadm RFC 7386 merge patch), json6902 (RFC 6902 JSON patch), or containerd-toml.",
+				Required:    true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: patchTypes},
+				},
+			},
+			"valid": schema.BoolAttribute{
+				Description: "Whether the patch parsed successfully.",
+				Computed:    true,
+			},
+			"error": schema.StringAttribute{
+				Description: "The parse error when the patch is invalid, empty otherwise.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *PatchValidationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PatchValidationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("kind-patch-validation")
+	data.Valid = types.BoolValue(true)
+	data.Error = types.StringValue("")
+
+	if err := validatePatch(data.Type.ValueString(), data.Patch.ValueString()); err != nil {
+		data.Valid = types.BoolValue(false)
+		data.Error = types.StringValue(err.Error())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
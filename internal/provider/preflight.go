@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// hostLimit describes a host kernel limit that KinD nodes consume, how much
+// of it each node needs and the value KinD recommends regardless of the node
+// count, if any.
+type hostLimit struct {
+	sysctl      string
+	path        string
+	perNode     int64
+	recommended int64
+}
+
+// hostLimits are the limits multi-node clusters most commonly exhaust. The
+// per-node values are what a node's kubelet, containerd and system pods use,
+// so the stock 128 instances and the older kernels' 8192 watches pass for the
+// default two nodes and fail as the node count grows. The recommended values
+// are those of the KinD known-issues guidance for a typical multi-node cluster.
+var hostLimits = []hostLimit{
+	{sysctl: "fs.inotify.max_user_instances", path: "/proc/sys/fs/inotify/max_user_instances", perNode: 32, recommended: 512},
+	{sysctl: "fs.inotify.max_user_watches", path: "/proc/sys/fs/inotify/max_user_watches", perNode: 4096, recommended: 524288},
+	{sysctl: "fs.file-max", path: "/proc/sys/fs/file-max", perNode: 65536},
+}
+
+// fileHandlesPath reports the allocated, free and maximum file handles of the
+// host kernel.
+const fileHandlesPath = "/proc/sys/fs/file-nr"
+
+// fileHandlesHeadroom is the share of fs.file-max that should still be free
+// before creating a cluster.
+const fileHandlesHeadroom = 0.1
+
+// checkHostLimits compares the host kernel limits against what nodeCount nodes
+// require. It returns one line per limit that is too low, including the
+// command needed to raise it. Limits that cannot be read are skipped, which
+// covers non-Linux hosts and remote Docker daemons.
+func checkHostLimits(nodeCount int) []string {
+	if !isLocalDockerHost() {
+		return nil
+	}
+
+	var problems []string
+	for _, limit := range hostLimits {
+		current, err := readSysctl(limit.path)
+		if err != nil {
+			continue
+		}
+
+		required := limit.perNode * int64(nodeCount)
+		if current < required {
+			problems = append(problems, fmt.Sprintf(
+				"%s is %d, need at least %d for %d node(s): sudo sysctl -w %s=%d",
+				limit.sysctl, current, required, nodeCount, limit.sysctl, required,
+			))
+		}
+	}
+
+	return problems
+}
+
+// checkHostRecommendations compares the host kernel limits against the values
+// KinD recommends, which the per-node minimums of checkHostLimits stay below
+// for small clusters, and checks that file handles are not close to running
+// out. Pods hitting these limits crash-loop with errors such as "too many open
+// files" long after the cluster was created. It returns one line per problem
+// with the command fixing it; limits that cannot be read are skipped.
+func checkHostRecommendations() []string {
+	if !isLocalDockerHost() {
+		return nil
+	}
+
+	var problems []string
+	for _, limit := range hostLimits {
+		if limit.recommended == 0 {
+			continue
+		}
+
+		current, err := readSysctl(limit.path)
+		if err != nil {
+			continue
+		}
+
+		if current < limit.recommended {
+			problems = append(problems, fmt.Sprintf(
+				"%s is %d, KinD recommends %d: sudo sysctl -w %s=%d",
+				limit.sysctl, current, limit.recommended, limit.sysctl, limit.recommended,
+			))
+		}
+	}
+
+	if content, err := os.ReadFile(fileHandlesPath); err == nil {
+		var allocated, free, maximum int64
+		if _, err := fmt.Sscan(string(content), &allocated, &free, &maximum); err == nil && maximum > 0 {
+			if float64(maximum-allocated) < float64(maximum)*fileHandlesHeadroom {
+				problems = append(problems, fmt.Sprintf(
+					"%d of %d file handles (fs.file-max) are in use: sudo sysctl -w fs.file-max=%d",
+					allocated, maximum, maximum*2,
+				))
+			}
+		}
+	}
+
+	return problems
+}
+
+// readSysctl reads a single integer value from a /proc/sys file.
+func readSysctl(path string) (int64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+}
+
+// isLocalDockerHost reports whether the container runtime runs on this host,
+// in which case the host kernel limits apply to the KinD nodes.
+func isLocalDockerHost() bool {
+	dockerHost := os.Getenv("DOCKER_HOST")
+	return dockerHost == "" || strings.HasPrefix(dockerHost, "unix://")
+}
+
+// hostNetwork is a network the host is attached to, either through one of
+// its interfaces or as a container runtime network.
+type hostNetwork struct {
+	source string
+	subnet *net.IPNet
+}
+
+// listHostNetworks returns the subnets of the host's non-loopback interfaces,
+// when the container runtime is local, and of the runtime's networks.
+// Networks that cannot be listed are skipped.
+func listHostNetworks(ctx context.Context, runtime string) []hostNetwork {
+	var networks []hostNetwork
+
+	if isLocalDockerHost() {
+		if ifaces, err := net.Interfaces(); err == nil {
+			for _, iface := range ifaces {
+				if iface.Flags&net.FlagLoopback != 0 {
+					continue
+				}
+				addrs, err := iface.Addrs()
+				if err != nil {
+					continue
+				}
+				for _, addr := range addrs {
+					if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.IsGlobalUnicast() {
+						_, subnet, _ := net.ParseCIDR(ipNet.String())
+						networks = append(networks, hostNetwork{source: "host interface " + iface.Name, subnet: subnet})
+					}
+				}
+			}
+		}
+	}
+
+	ids, err := runContainerCommand(ctx, runtime, "network", "ls", "--quiet")
+	if err != nil || ids == "" {
+		return networks
+	}
+
+	out, err := runContainerCommand(ctx, runtime, append([]string{"network", "inspect"}, strings.Fields(ids)...)...)
+	if err != nil {
+		return networks
+	}
+
+	var inspected []struct {
+		Name string
+		IPAM struct {
+			Config []struct {
+				Subnet string
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(out), &inspected); err != nil {
+		return networks
+	}
+
+	for _, n := range inspected {
+		for _, c := range n.IPAM.Config {
+			if _, subnet, err := net.ParseCIDR(c.Subnet); err == nil {
+				networks = append(networks, hostNetwork{source: fmt.Sprintf("%s network %s", runtime, n.Name), subnet: subnet})
+			}
+		}
+	}
+
+	return networks
+}
+
+// checkSubnetOverlap returns one line per cluster subnet, given by name as
+// comma-separated CIDRs, that overlaps a host network. Traffic to addresses
+// in both is routed unpredictably between the cluster and the host network.
+func checkSubnetOverlap(subnets map[string]string, networks []hostNetwork) []string {
+	names := make([]string, 0, len(subnets))
+	for name := range subnets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		for _, cidr := range strings.Split(subnets[name], ",") {
+			_, subnet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+			if err != nil {
+				continue
+			}
+
+			for _, n := range networks {
+				if subnet.Contains(n.subnet.IP) || n.subnet.Contains(subnet.IP) {
+					problems = append(problems, fmt.Sprintf("%s %s overlaps %s (%s)", name, subnet, n.source, n.subnet))
+				}
+			}
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,29 @@
+package provider
+
+import "testing"
+
+// TestHostLimitsFitStockHosts keeps the per-node minimums in line with the
+// check_host_limits default: stock limits must pass for the default two
+// nodes, and the minimums must stay below KinD's recommendations for them.
+func TestHostLimitsFitStockHosts(t *testing.T) {
+	stock := map[string]int64{
+		"fs.inotify.max_user_instances": 128,
+		"fs.inotify.max_user_watches":   8192,
+		"fs.file-max":                   9223372036854775807,
+	}
+
+	for _, limit := range hostLimits {
+		current, ok := stock[limit.sysctl]
+		if !ok {
+			t.Errorf("no stock value for %s", limit.sysctl)
+			continue
+		}
+
+		if required := limit.perNode * 2; required > current {
+			t.Errorf("%s needs %d for two nodes, above the stock %d", limit.sysctl, required, current)
+		}
+		if limit.recommended != 0 && limit.perNode*2 > limit.recommended {
+			t.Errorf("%s minimum for two nodes is above the recommended %d", limit.sysctl, limit.recommended)
+		}
+	}
+}
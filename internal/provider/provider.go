@@ -5,9 +5,11 @@ import (
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"sigs.k8s.io/kind/pkg/cluster"
 )
@@ -17,10 +19,32 @@ var _ provider.Provider = &KindProvider{}
 type KindProvider struct {
 	version         string
 	clusterProvider *cluster.Provider
+	// defaultNodeImage is used by clusters that do not set node_image.
+	defaultNodeImage string
 }
 
 type KindProviderModel struct {
-	Host types.String `tfsdk:"host"`
+	Host             types.String `tfsdk:"host"`
+	DockerHost       types.String `tfsdk:"docker_host"`
+	ProviderRuntime  types.String `tfsdk:"provider_runtime"`
+	MetricsFile      types.String `tfsdk:"metrics_file"`
+	DiagnosticsFile  types.String `tfsdk:"diagnostics_file"`
+	LogLevel         types.String `tfsdk:"log_level"`
+	DefaultNodeImage types.String `tfsdk:"default_node_image"`
+}
+
+// KindProviderData is handed to resources and data sources on Configure.
+type KindProviderData struct {
+	Provider *cluster.Provider
+	// Runtime is the container runtime CLI for node operations the kind
+	// library does not expose.
+	Runtime         string
+	MetricsFile     string
+	DiagnosticsFile string
+	// Version is the provider's own release version.
+	Version string
+	// DefaultNodeImage is the node image of clusters without node_image.
+	DefaultNodeImage string
 }
 
 func New(version string) func() provider.Provider {
@@ -41,7 +65,44 @@ func (p *KindProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 		Description: "Terraform provider for KinD (Kubernetes in Docker) clusters.",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Description: "Docker daemon endpoint (e.g., unix:///var/run/docker.sock or tcp://localhost:2375). Sets the DOCKER_HOST environment variable for kind operations.",
+				Description:        "Docker daemon endpoint (e.g., unix:///var/run/docker.sock or tcp://localhost:2375). Sets the DOCKER_HOST environment variable for kind operations.",
+				Optional:           true,
+				DeprecationMessage: "Use docker_host instead.",
+				Validators: []validator.String{
+					dockerHostValidator{lenient: true},
+				},
+			},
+			"docker_host": schema.StringAttribute{
+				Description: "Docker daemon endpoint (e.g., unix:///run/user/1000/docker.sock or ssh://user@host). Exported as DOCKER_HOST before the kind provider is created. Terraform runs each provider alias in its own process, so it only affects clusters created through this provider alias.",
+				Optional:    true,
+				Validators: []validator.String{
+					dockerHostValidator{},
+				},
+			},
+			"provider_runtime": schema.StringAttribute{
+				Description: "Container runtime KinD uses for the node containers: docker, podman, nerdctl, or the nerdctl compatible finch and nerdctl.lima. When unset, KIND_EXPERIMENTAL_PROVIDER is honoured like the kind CLI does, and the runtime is auto-detected without it.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: containerRuntimes},
+				},
+			},
+			"metrics_file": schema.StringAttribute{
+				Description: "Path to a Prometheus textfile-collector file. When set, every create, update and delete of a cluster appends its duration, node count and result to it; refreshes are not recorded. Writes are best-effort and never fail an apply.",
+				Optional:    true,
+			},
+			"diagnostics_file": schema.StringAttribute{
+				Description: "Path to a JSON Lines file. When set, every create, read, update and delete of a cluster appends a record with its time, operation, cluster name, duration, success and error summary. Writes are best-effort and never fail an apply.",
+				Optional:    true,
+			},
+			"log_level": schema.StringAttribute{
+				Description: "Verbosity of the kind library's own logging, forwarded to the provider log shown with TF_LOG: trace, debug, info or warn. info forwards the progress messages the kind CLI prints by default. Defaults to info.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: kindLogLevels},
+				},
+			},
+			"default_node_image": schema.StringAttribute{
+				Description: "Node image for clusters that do not set node_image, to pin one kindest/node version across all clusters of this provider block. A cluster's node_image overrides it. Changing it does not affect existing clusters, which keep the image recorded in their node_image.",
 				Optional:    true,
 			},
 		},
@@ -55,13 +116,62 @@ func (p *KindProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	if !config.Host.IsNull() && config.Host.ValueString() != "" {
-		os.Setenv("DOCKER_HOST", config.Host.ValueString())
+	dockerHost := config.DockerHost.ValueString()
+	if dockerHost == "" {
+		dockerHost = config.Host.ValueString()
+	} else if !config.Host.IsNull() && config.Host.ValueString() != dockerHost {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("docker_host"),
+			"Conflicting Docker Host",
+			"host and docker_host are set to different values. Remove the deprecated host attribute.",
+		)
+		return
+	}
+
+	if dockerHost != "" {
+		if err := os.Setenv("DOCKER_HOST", dockerHost); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("docker_host"), "Failed to set DOCKER_HOST", err.Error())
+			return
+		}
+	}
+
+	runtime := config.ProviderRuntime.ValueString()
+	if runtime == "" {
+		envRuntime, err := experimentalProviderRuntime()
+		if err != nil {
+			resp.Diagnostics.AddWarning("Ignoring "+kindExperimentalProviderEnv, err.Error())
+		}
+		runtime = envRuntime
+	}
+
+	providerOpts, err := clusterProviderOptions(runtime)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("provider_runtime"), "Invalid Container Runtime", err.Error())
+		return
+	}
+	if runtime == "" {
+		runtime = detectContainerRuntime()
+	}
+
+	logLevel := config.LogLevel.ValueString()
+	if logLevel == "" {
+		logLevel = defaultKindLogLevel
 	}
+	providerOpts = append(providerOpts, cluster.ProviderWithLogger(newKindLogger(ctx, logLevel)))
+
+	p.clusterProvider = cluster.NewProvider(providerOpts...)
+	p.defaultNodeImage = config.DefaultNodeImage.ValueString()
 
-	p.clusterProvider = cluster.NewProvider()
-	resp.ResourceData = p.clusterProvider
-	resp.DataSourceData = p.clusterProvider
+	providerData := &KindProviderData{
+		Provider:         p.clusterProvider,
+		Runtime:          runtime,
+		MetricsFile:      config.MetricsFile.ValueString(),
+		DiagnosticsFile:  config.DiagnosticsFile.ValueString(),
+		Version:          p.version,
+		DefaultNodeImage: p.defaultNodeImage,
+	}
+	resp.ResourceData = providerData
+	resp.DataSourceData = providerData
 }
 
 func (p *KindProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -72,6 +182,15 @@ func (p *KindProvider) Resources(_ context.Context) []func() resource.Resource {
 
 func (p *KindProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewClusterDataSource,
+		NewClusterExistsDataSource,
+		NewClusterNodesDataSource,
 		NewClustersDataSource,
+		NewDefaultNodeImageDataSource,
+		NewDockerNetworkDataSource,
+		NewKubeconfigDataSource,
+		NewNodeImageDataSource,
+		NewPatchValidationDataSource,
+		NewProviderVersionDataSource,
 	}
 }
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testAccProtoV6ProviderFactories serves the provider to the Terraform CLI
+// in acceptance tests, which run only with TF_ACC set and need a container
+// runtime KinD can create clusters with.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"kind": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// TestProviderSchema fails on schema errors the framework only reports to
+// Terraform, such as reserved attribute names.
+func TestProviderSchema(t *testing.T) {
+	server := providerserver.NewProtocol6(New("test")())()
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+// testAccCheckReadyNodes connects to the cluster with the kubeconfig in the
+// resource's state and checks that it has want nodes, all Ready.
+func testAccCheckReadyNodes(resourceName string, want int) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		clientset, err := newKubernetesClientset(rs.Primary.Attributes["kubeconfig"])
+		if err != nil {
+			return fmt.Errorf("kubeconfig of %s is not usable: %w", resourceName, err)
+		}
+
+		nodeList, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("listing nodes with the kubeconfig of %s: %w", resourceName, err)
+		}
+		if len(nodeList.Items) != want {
+			return fmt.Errorf("cluster has %d nodes, want %d", len(nodeList.Items), want)
+		}
+
+		for _, node := range nodeList.Items {
+			ready := false
+			for _, condition := range node.Status.Conditions {
+				if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+					ready = true
+				}
+			}
+			if !ready {
+				return fmt.Errorf("node %s is not Ready", node.Name)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/kind/pkg/apis/config/defaults"
+)
+
+var _ datasource.DataSource = &ProviderVersionDataSource{}
+
+type ProviderVersionDataSource struct {
+	version string
+}
+
+func NewProviderVersionDataSource() datasource.DataSource {
+	return &ProviderVersionDataSource{}
+}
+
+func (d *ProviderVersionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_version"
+}
+
+func (d *ProviderVersionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Return the provider version, the kind library version it is built with and the node images that kind release ships, for gating modules on features of a kind release.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (same as kind_version).",
+				Computed:    true,
+			},
+			"provider_version": schema.StringAttribute{
+				Description: "Release version of the provider, as set at build time.",
+				Computed:    true,
+			},
+			"kind_version": schema.StringAttribute{
+				Description: "Version of the kind library the provider is built with, without the leading v.",
+				Computed:    true,
+			},
+			"default_node_image": schema.StringAttribute{
+				Description: "Digest-pinned node image kind uses when node_image is not set.",
+				Computed:    true,
+			},
+			"node_images": schema.ListAttribute{
+				Description: "Digest-pinned node images known for the kind release, in the order of kubernetes_versions. This is the release's default image, the one the kind library pins.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"kubernetes_versions": schema.ListAttribute{
+				Description: "Kubernetes versions of node_images, oldest first, without the leading v.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *ProviderVersionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KindProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *KindProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.version = providerData.Version
+}
+
+func (d *ProviderVersionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProviderVersionDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	images := knownNodeImages()
+	versions := make([]string, 0, len(images))
+	for v := range images {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		a, errA := version.ParseGeneric(versions[i])
+		b, errB := version.ParseGeneric(versions[j])
+		if errA != nil || errB != nil {
+			return versions[i] < versions[j]
+		}
+		return a.LessThan(b)
+	})
+
+	nodeImages := make([]string, len(versions))
+	for i, v := range versions {
+		nodeImages[i] = images[v]
+	}
+
+	kindVersion := kindLibraryVersion()
+	data.ID = types.StringValue(kindVersion)
+	data.ProviderVersion = types.StringValue(d.version)
+	data.KindVersion = types.StringValue(kindVersion)
+	data.DefaultNodeImage = types.StringValue(defaults.Image)
+
+	nodeImagesValue, diags := types.ListValueFrom(ctx, types.StringType, nodeImages)
+	resp.Diagnostics.Append(diags...)
+	data.NodeImages = nodeImagesValue
+
+	versionsValue, diags := types.ListValueFrom(ctx, types.StringType, versions)
+	resp.Diagnostics.Append(diags...)
+	data.KubernetesVersions = versionsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// rbacKinds are the kinds accepted in rbac.manifests, in the order they are
+// applied so roles exist before the bindings referring to them.
+var rbacKinds = []string{"ClusterRole", "Role", "ClusterRoleBinding", "RoleBinding"}
+
+// rbacManifest is one RBAC object parsed from rbac.manifests.
+type rbacManifest struct {
+	kind string
+	doc  []byte
+}
+
+// parseRBACManifests splits the configured manifests into their YAML
+// documents and checks that each is an rbac.authorization.k8s.io/v1 object
+// of one of rbacKinds.
+func parseRBACManifests(manifests []string) ([]rbacManifest, error) {
+	var parsed []rbacManifest
+	for i, manifest := range manifests {
+		reader := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(manifest)))
+		for {
+			doc, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("manifest %d: %w", i, err)
+			}
+
+			var meta metav1.TypeMeta
+			if err := yaml.Unmarshal(doc, &meta); err != nil {
+				return nil, fmt.Errorf("manifest %d: %w", i, err)
+			}
+			if meta.Kind == "" && meta.APIVersion == "" {
+				// Empty document, e.g. a trailing separator.
+				continue
+			}
+
+			if meta.APIVersion != rbacv1.SchemeGroupVersion.String() || !slices.Contains(rbacKinds, meta.Kind) {
+				return nil, fmt.Errorf("manifest %d: %s %s is not an RBAC object, use %s with kind %s",
+					i, meta.APIVersion, meta.Kind, rbacv1.SchemeGroupVersion.String(), strings.Join(rbacKinds, ", "))
+			}
+
+			parsed = append(parsed, rbacManifest{kind: meta.Kind, doc: doc})
+		}
+	}
+
+	// Stable, so manifests of the same kind keep their configured order.
+	slices.SortStableFunc(parsed, func(a, b rbacManifest) int {
+		return slices.Index(rbacKinds, a.kind) - slices.Index(rbacKinds, b.kind)
+	})
+
+	return parsed, nil
+}
+
+// validateRBACManifests reports manifests that are not RBAC objects at plan
+// time.
+func validateRBACManifests(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.RBAC == nil || data.RBAC.Manifests.IsUnknown() {
+		return
+	}
+
+	if _, err := parseRBACManifests(listStringValues(data.RBAC.Manifests)); err != nil {
+		diagnostics.AddAttributeError(path.Root("rbac").AtName("manifests"), "Invalid RBAC Manifest", err.Error())
+	}
+}
+
+// applyRBACManifests creates the configured RBAC objects, roles before
+// bindings, and updates objects that already exist. Namespaced objects
+// without a namespace go to the default namespace.
+func applyRBACManifests(ctx context.Context, clientset kubernetes.Interface, manifests []string) error {
+	parsed, err := parseRBACManifests(manifests)
+	if err != nil {
+		return err
+	}
+
+	rbac := clientset.RbacV1()
+	for _, m := range parsed {
+		switch m.kind {
+		case "ClusterRole":
+			var obj rbacv1.ClusterRole
+			if err := yaml.Unmarshal(m.doc, &obj); err != nil {
+				return err
+			}
+			_, err = rbac.ClusterRoles().Create(ctx, &obj, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				_, err = rbac.ClusterRoles().Update(ctx, &obj, metav1.UpdateOptions{})
+			}
+		case "Role":
+			var obj rbacv1.Role
+			if err := yaml.Unmarshal(m.doc, &obj); err != nil {
+				return err
+			}
+			obj.Namespace = namespaceOrDefault(obj.Namespace)
+			_, err = rbac.Roles(obj.Namespace).Create(ctx, &obj, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				_, err = rbac.Roles(obj.Namespace).Update(ctx, &obj, metav1.UpdateOptions{})
+			}
+		case "ClusterRoleBinding":
+			var obj rbacv1.ClusterRoleBinding
+			if err := yaml.Unmarshal(m.doc, &obj); err != nil {
+				return err
+			}
+			_, err = rbac.ClusterRoleBindings().Create(ctx, &obj, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				_, err = rbac.ClusterRoleBindings().Update(ctx, &obj, metav1.UpdateOptions{})
+			}
+		case "RoleBinding":
+			var obj rbacv1.RoleBinding
+			if err := yaml.Unmarshal(m.doc, &obj); err != nil {
+				return err
+			}
+			obj.Namespace = namespaceOrDefault(obj.Namespace)
+			_, err = rbac.RoleBindings(obj.Namespace).Create(ctx, &obj, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				_, err = rbac.RoleBindings(obj.Namespace).Update(ctx, &obj, metav1.UpdateOptions{})
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply %s: %w", m.kind, err)
+		}
+	}
+
+	return nil
+}
+
+// namespaceOrDefault returns namespace, or the default namespace when empty.
+func namespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return metav1.NamespaceDefault
+	}
+
+	return namespace
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+)
+
+// registryCertsDir is the containerd registry host configuration directory.
+// KinD node images point the CRI plugin's config_path at it, and containerd
+// trusts any ca.crt found in a registry's host directory.
+const registryCertsDir = "/etc/containerd/certs.d"
+
+// registryHostPattern matches a registry host[:port] as containerd names its
+// host directories: a host name, an IPv4 address or a bracketed IPv6 address,
+// or _default for the fallback configuration.
+var registryHostPattern = regexp.MustCompile(`^(\[[0-9A-Fa-f:.]+\]|[A-Za-z0-9_]([A-Za-z0-9._-]*[A-Za-z0-9])?)(:[0-9]{1,5})?$`)
+
+// applyRegistryCerts installs the configured registry CA certificates on
+// every node and restarts containerd so registry clients pick them up.
+func applyRegistryCerts(ctx context.Context, provider *cluster.Provider, clusterName string, certs map[string]string, diagnostics *diag.Diagnostics) {
+	if len(certs) == 0 {
+		return
+	}
+
+	nodeList, err := provider.ListNodes(clusterName)
+	if err != nil {
+		diagnostics.AddError("Failed to install registry certificates", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	if err := installRegistryCerts(ctx, nodeList, certs); err != nil {
+		diagnostics.AddError("Failed to install registry certificates", err.Error())
+	}
+}
+
+// installRegistryCerts writes the registry CA certificates to the given nodes
+// and restarts their containerd.
+func installRegistryCerts(ctx context.Context, nodeList []nodes.Node, certs map[string]string) error {
+	hosts := make([]string, 0, len(certs))
+	for host := range certs {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, node := range nodeList {
+		for _, host := range hosts {
+			if err := writeRegistryHostFile(ctx, node, host, "ca.crt", certs[host]); err != nil {
+				return fmt.Errorf("writing the CA certificate for %s on node %s failed: %w", host, node.String(), err)
+			}
+		}
+
+		if err := node.CommandContext(ctx, "systemctl", "restart", "containerd").Run(); err != nil {
+			return fmt.Errorf("restarting containerd on node %s failed: %w", node.String(), err)
+		}
+	}
+
+	return nil
+}
+
+// writeRegistryHostFile writes a file to a registry's host directory on a
+// node. The directory is passed to the shell as a positional argument rather
+// than spliced into the script, so no registry name can run commands.
+func writeRegistryHostFile(ctx context.Context, node nodes.Node, host, name, content string) error {
+	cmd := node.CommandContext(ctx, "sh", "-c", `mkdir -p "$1" && cat > "$1/$2"`, "sh", path.Join(registryCertsDir, host), name)
+	cmd.SetStdin(strings.NewReader(content))
+	return cmd.Run()
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+)
+
+// registryServers maps registry names to their API endpoint where the two
+// differ, as containerd's own defaulting does.
+var registryServers = map[string]string{
+	"docker.io": "https://registry-1.docker.io",
+}
+
+// renderRegistryHostsTOML renders the containerd hosts.toml for a registry,
+// sending pulls to its mirrors in order before falling back to the registry
+// itself. Registries and mirrors with a certificate in registry_certs get it
+// set as their CA, since containerd only loads a directory's certificates on
+// its own when there is no hosts.toml.
+func renderRegistryHostsTOML(endpoint string, mirrors []string, certs map[string]string) string {
+	server, ok := registryServers[endpoint]
+	if !ok {
+		server = "https://" + endpoint
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "server = %q\n", server)
+	if _, ok := certs[endpoint]; ok {
+		fmt.Fprintf(&b, "ca = %q\n", path.Join(registryCertsDir, endpoint, "ca.crt"))
+	}
+
+	for _, mirror := range mirrors {
+		fmt.Fprintf(&b, "\n[host.%q]\n", mirror)
+		fmt.Fprintf(&b, "  capabilities = [\"pull\", \"resolve\"]\n")
+
+		host := strings.TrimPrefix(strings.TrimPrefix(mirror, "https://"), "http://")
+		host, _, _ = strings.Cut(host, "/")
+		if _, ok := certs[host]; ok {
+			fmt.Fprintf(&b, "  ca = %q\n", path.Join(registryCertsDir, host, "ca.crt"))
+		}
+	}
+
+	return b.String()
+}
+
+// applyRegistryMirrors configures the registry mirrors on every node.
+func applyRegistryMirrors(ctx context.Context, provider *cluster.Provider, clusterName string, mirrors []RegistryMirrorModel, certs map[string]string, diagnostics *diag.Diagnostics) {
+	if len(mirrors) == 0 {
+		return
+	}
+
+	nodeList, err := provider.ListNodes(clusterName)
+	if err != nil {
+		diagnostics.AddError("Failed to configure registry mirrors", fmt.Sprintf("Could not list cluster nodes: %s", err))
+		return
+	}
+
+	if err := installRegistryMirrors(ctx, nodeList, mirrors, certs); err != nil {
+		diagnostics.AddError("Failed to configure registry mirrors", err.Error())
+	}
+}
+
+// installRegistryMirrors writes a hosts.toml per mirrored registry to the
+// given nodes. containerd reads them on every pull, so no restart is needed.
+func installRegistryMirrors(ctx context.Context, nodeList []nodes.Node, mirrors []RegistryMirrorModel, certs map[string]string) error {
+	for _, node := range nodeList {
+		for _, mirror := range mirrors {
+			endpoint := mirror.Endpoint.ValueString()
+			hostsTOML := renderRegistryHostsTOML(endpoint, listStringValues(mirror.Mirrors), certs)
+			if err := writeRegistryHostFile(ctx, node, endpoint, "hosts.toml", hostsTOML); err != nil {
+				return fmt.Errorf("writing the mirrors for %s on node %s failed: %w", endpoint, node.String(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRegistryMirrors checks the registry names and mirror URLs and
+// rejects registries configured more than once.
+func validateRegistryMirrors(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	seen := map[string]bool{}
+	for i, mirror := range data.RegistryMirrors {
+		attrPath := tfpath.Root("registry_mirror").AtListIndex(i)
+
+		if !mirror.Endpoint.IsUnknown() {
+			endpoint := mirror.Endpoint.ValueString()
+			if !registryHostPattern.MatchString(endpoint) {
+				diagnostics.AddAttributeError(
+					attrPath.AtName("endpoint"),
+					"Invalid Registry Host",
+					fmt.Sprintf("%q is not a valid registry host, use host or host:port without a scheme or path, e.g. docker.io.", endpoint),
+				)
+			}
+
+			if seen[endpoint] {
+				diagnostics.AddAttributeError(
+					attrPath.AtName("endpoint"),
+					"Duplicate Registry Mirror",
+					fmt.Sprintf("Registry %q has more than one registry_mirror block.", endpoint),
+				)
+			}
+			seen[endpoint] = true
+		}
+
+		if mirror.Mirrors.IsUnknown() {
+			continue
+		}
+		for j, m := range listStringValues(mirror.Mirrors) {
+			u, err := url.Parse(m)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				diagnostics.AddAttributeError(
+					attrPath.AtName("mirrors").AtListIndex(j),
+					"Invalid Mirror URL",
+					fmt.Sprintf("%q is not valid, use an http or https URL such as http://registry-cache:5000.", m),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// restartedAtAnnotation is the pod template annotation `kubectl rollout
+// restart` sets; changing it rolls the workload's pods.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// restartableKinds are the workload kinds restart_workloads accepts, in
+// lower case as they appear in the targets.
+var restartableKinds = []string{"deployment", "statefulset", "daemonset"}
+
+// workloadRef is a restart_workloads target.
+type workloadRef struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+func (w workloadRef) String() string {
+	return w.namespace + "/" + w.kind + "/" + w.name
+}
+
+// parseWorkloadRef parses a namespace/kind/name target. The kind is matched
+// case-insensitively.
+func parseWorkloadRef(target string) (workloadRef, error) {
+	parts := strings.Split(target, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return workloadRef{}, fmt.Errorf("%q is not a namespace/kind/name target, e.g. default/deployment/web", target)
+	}
+
+	ref := workloadRef{namespace: parts[0], kind: strings.ToLower(parts[1]), name: parts[2]}
+	for _, kind := range restartableKinds {
+		if ref.kind == kind {
+			return ref, nil
+		}
+	}
+
+	return workloadRef{}, fmt.Errorf("%q has kind %q, expected one of %s", target, parts[1], strings.Join(restartableKinds, ", "))
+}
+
+// restartWorkloads rolls the pods of the restart_workloads targets the way
+// `kubectl rollout restart` does, so they start again from the freshly loaded
+// images. Every target is checked to exist before any is restarted.
+func restartWorkloads(ctx context.Context, data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	targets := listStringValues(data.RestartWorkloads)
+	if len(targets) == 0 {
+		return
+	}
+
+	clientset, err := newKubernetesClientset(clientKubeconfig(data))
+	if err != nil {
+		diagnostics.AddError("Failed to restart workloads", err.Error())
+		return
+	}
+
+	refs := make([]workloadRef, 0, len(targets))
+	for _, target := range targets {
+		ref, err := parseWorkloadRef(target)
+		if err != nil {
+			diagnostics.AddError("Failed to restart workloads", err.Error())
+			return
+		}
+		if err := getWorkload(ctx, clientset, ref); err != nil {
+			diagnostics.AddError("Failed to restart workloads", fmt.Sprintf("Workload %s could not be read: %s", ref, err))
+		}
+		refs = append(refs, ref)
+	}
+	if diagnostics.HasError() {
+		return
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+	for _, ref := range refs {
+		if err := patchWorkload(ctx, clientset, ref, patch); err != nil {
+			diagnostics.AddError("Failed to restart workloads", fmt.Sprintf("Restarting %s failed: %s", ref, err))
+			return
+		}
+	}
+}
+
+// getWorkload reads a workload, failing when it does not exist.
+func getWorkload(ctx context.Context, clientset kubernetes.Interface, ref workloadRef) error {
+	var err error
+	switch ref.kind {
+	case "deployment":
+		_, err = clientset.AppsV1().Deployments(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	case "statefulset":
+		_, err = clientset.AppsV1().StatefulSets(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	case "daemonset":
+		_, err = clientset.AppsV1().DaemonSets(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	}
+
+	return err
+}
+
+// patchWorkload applies a strategic merge patch to a workload.
+func patchWorkload(ctx context.Context, clientset kubernetes.Interface, ref workloadRef, patch []byte) error {
+	var err error
+	switch ref.kind {
+	case "deployment":
+		_, err = clientset.AppsV1().Deployments(ref.namespace).Patch(ctx, ref.name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "statefulset":
+		_, err = clientset.AppsV1().StatefulSets(ref.namespace).Patch(ctx, ref.name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "daemonset":
+		_, err = clientset.AppsV1().DaemonSets(ref.namespace).Patch(ctx, ref.name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	}
+
+	return err
+}
+
+// validateRestartWorkloads checks the restart_workloads target format. Whether
+// the targets exist is only known once images are loaded.
+func validateRestartWorkloads(data *ClusterResourceModel, diagnostics *diag.Diagnostics) {
+	if data.RestartWorkloads.IsNull() || data.RestartWorkloads.IsUnknown() {
+		return
+	}
+
+	for i, elem := range data.RestartWorkloads.Elements() {
+		target, ok := elem.(types.String)
+		if !ok || target.IsNull() || target.IsUnknown() {
+			continue
+		}
+
+		if _, err := parseWorkloadRef(target.ValueString()); err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("restart_workloads").AtListIndex(i),
+				"Invalid Workload Target",
+				err.Error(),
+			)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// Operation timeouts used when the timeouts block leaves them unset.
+const (
+	defaultCreateTimeout = 15 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 15 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
+
+// timeoutsBlock returns the schema of the timeouts block, the standard
+// terraform-plugin-framework-timeouts block with descriptions stating the
+// defaults and what each deadline bounds.
+func timeoutsBlock(ctx context.Context) schema.Block {
+	description := func(operation string, def time.Duration) string {
+		return "Deadline for the " + operation + " operation as a duration, e.g. 30m. Default is " + def.String() + "."
+	}
+
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: description("create", defaultCreateTimeout) + " Readiness waits and post-create steps stop at this deadline even if wait_for_ready is longer.",
+		ReadDescription:   description("read", defaultReadTimeout),
+		UpdateDescription: description("update", defaultUpdateTimeout),
+		DeleteDescription: description("delete", defaultDeleteTimeout) + " When KinD has not deleted the cluster by this deadline, its node containers are force-removed with a warning.",
+	})
+}
+
+// operationContext returns a context bounded by the configured timeout for the
+// operation, or its default.
+func operationContext(ctx context.Context, value timeouts.Value, operation string, diagnostics *diag.Diagnostics) (context.Context, context.CancelFunc, time.Duration) {
+	var timeout time.Duration
+	var diags diag.Diagnostics
+
+	switch operation {
+	case "create":
+		timeout, diags = value.Create(ctx, defaultCreateTimeout)
+	case "read":
+		timeout, diags = value.Read(ctx, defaultReadTimeout)
+	case "update":
+		timeout, diags = value.Update(ctx, defaultUpdateTimeout)
+	case "delete":
+		timeout, diags = value.Delete(ctx, defaultDeleteTimeout)
+	}
+	diagnostics.Append(diags...)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, timeout
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+)
+
+// topologyRoles are the node roles in the order the summary lists them.
+var topologyRoles = []string{
+	constants.ControlPlaneNodeRoleValue,
+	constants.WorkerNodeRoleValue,
+	constants.ExternalLoadBalancerNodeRoleValue,
+}
+
+// topologySummary describes the cluster for humans: its nodes by role, its
+// networking with KinD's defaults filled in, its CNI and the notable features
+// the provider set up. It is derived from the configuration and the node list
+// only, so it stays the same between refreshes.
+func (r *ClusterResource) topologySummary(data *ClusterResourceModel, nodeNames []NodeNameModel) string {
+	var b strings.Builder
+
+	image := data.NodeImage.ValueString()
+	if image == "" {
+		image = "kind default (" + defaultNodeImageVersion() + ")"
+	}
+	fmt.Fprintf(&b, "Cluster %s, node image %s\n", data.Name.ValueString(), image)
+
+	counts := map[string]int{}
+	for _, node := range nodeNames {
+		counts[node.Role.ValueString()]++
+	}
+	var nodes []string
+	for _, role := range topologyRoles {
+		if counts[role] > 0 {
+			nodes = append(nodes, fmt.Sprintf("%d %s", counts[role], role))
+		}
+	}
+	fmt.Fprintf(&b, "Nodes: %s\n", strings.Join(nodes, ", "))
+
+	cfg := &v1alpha4.Cluster{}
+	if data.Networking != nil {
+		cfg.Networking = r.buildNetworkingConfig(data.Networking)
+	}
+	v1alpha4.SetDefaultsCluster(cfg)
+	fmt.Fprintf(&b, "Networking: %s, pods %s, services %s, kube-proxy %s\n",
+		cfg.Networking.IPFamily, cfg.Networking.PodSubnet, cfg.Networking.ServiceSubnet, cfg.Networking.KubeProxyMode)
+
+	cni := "kindnet (default)"
+	switch {
+	case !data.CNIManifest.IsNull() && data.CNIManifest.ValueString() != "":
+		cni = "custom manifest " + data.CNIManifest.ValueString()
+	case cfg.Networking.DisableDefaultCNI:
+		cni = "default disabled, none installed by the provider"
+	}
+	fmt.Fprintf(&b, "CNI: %s\n", cni)
+
+	features := topologyFeatures(data)
+	if len(features) == 0 {
+		features = []string{"none"}
+	}
+	fmt.Fprintf(&b, "Features: %s\n", strings.Join(features, ", "))
+
+	return b.String()
+}
+
+// topologyFeatures lists the notable features enabled in the configuration,
+// in a fixed order.
+func topologyFeatures(data *ClusterResourceModel) []string {
+	var features []string
+
+	if data.InstallCertManager.ValueBool() {
+		features = append(features, "cert-manager "+certManagerVersion(data))
+	}
+	if data.InstallNodeLocalDNS.ValueBool() {
+		features = append(features, "NodeLocal DNSCache")
+	}
+	if data.CoreDNS != nil {
+		features = append(features, "custom CoreDNS")
+	}
+	if auditPolicy(data) != "" {
+		features = append(features, "audit logging")
+	}
+	if len(data.EventRateLimits) > 0 {
+		features = append(features, "EventRateLimit admission")
+	}
+	if data.APIServerTracing != nil {
+		features = append(features, "API server tracing")
+	}
+	if data.LocalRegistry != nil {
+		features = append(features, "local registry at "+localRegistryHost(data.LocalRegistry))
+	}
+	if n := len(data.RegistryMirrors); n > 0 {
+		features = append(features, fmt.Sprintf("%d registry mirror(s)", n))
+	}
+	if data.EnableImageCachePassthrough.ValueBool() {
+		features = append(features, "image cache passthrough")
+	}
+	if data.GracefulNodeShutdown != nil {
+		features = append(features, "graceful node shutdown")
+	}
+	if data.DrainBeforeDelete != nil {
+		features = append(features, "drain before delete")
+	}
+
+	gates := boolMapValue(data.FeatureGates)
+	names := make([]string, 0, len(gates))
+	for name := range gates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		features = append(features, fmt.Sprintf("feature gate %s=%t", name, gates[name]))
+	}
+
+	return features
+}
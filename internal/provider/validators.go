@@ -0,0 +1,757 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var _ validator.String = hostPortValidator{}
+
+// hostPortValidator checks that a string is a host:port address with a valid
+// port number. The host part may be empty to listen on all addresses.
+type hostPortValidator struct{}
+
+func (v hostPortValidator) Description(_ context.Context) string {
+	return "value must be a host:port address"
+}
+
+func (v hostPortValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostPortValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	_, port, err := net.SplitHostPort(value)
+	if err == nil {
+		var portNum int
+		portNum, err = strconv.Atoi(port)
+		if err == nil && (portNum < 1 || portNum > 65535) {
+			err = fmt.Errorf("port %d is out of range 1-65535", portNum)
+		}
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Address",
+			fmt.Sprintf("%q is not a valid host:port address: %s", value, err),
+		)
+	}
+}
+
+var _ validator.Map = resourceListValidator{}
+
+// resourceListValidator checks that a map of resource names to quantities only
+// uses the allowed resource names and that every value is a valid quantity.
+type resourceListValidator struct {
+	allowed []string
+}
+
+func (v resourceListValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("keys must be one of %s and values must be Kubernetes quantities", strings.Join(v.allowed, ", "))
+}
+
+func (v resourceListValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v resourceListValidator) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for key, elem := range req.ConfigValue.Elements() {
+		if !slices.Contains(v.allowed, key) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(key),
+				"Invalid Resource Name",
+				fmt.Sprintf("%q is not supported. Allowed resources: %s.", key, strings.Join(v.allowed, ", ")),
+			)
+			continue
+		}
+
+		strVal, ok := elem.(types.String)
+		if !ok || strVal.IsNull() || strVal.IsUnknown() {
+			continue
+		}
+
+		if _, err := resource.ParseQuantity(strVal.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(key),
+				"Invalid Resource Quantity",
+				fmt.Sprintf("%q is not a valid quantity: %s", strVal.ValueString(), err),
+			)
+		}
+	}
+}
+
+var _ validator.String = stringOneOfValidator{}
+
+// stringOneOfValidator checks that a string is one of a fixed set of values,
+// or empty when allowEmpty is set.
+type stringOneOfValidator struct {
+	values     []string
+	allowEmpty bool
+}
+
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	if v.allowEmpty {
+		return fmt.Sprintf("value must be empty or one of: %s", strings.Join(v.values, ", "))
+	}
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if v.allowEmpty && req.ConfigValue.ValueString() == "" {
+		return
+	}
+
+	if !slices.Contains(v.values, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("%q is not valid, %s.", req.ConfigValue.ValueString(), v.Description(ctx)),
+		)
+	}
+}
+
+var (
+	_ validator.Int64 = int64AtLeastValidator{}
+	_ validator.Map   = int64AtLeastValidator{}
+)
+
+// int64AtLeastValidator checks that an integer, or every integer in a map, is
+// at least min.
+type int64AtLeastValidator struct {
+	min int64
+}
+
+func (v int64AtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+func (v int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64AtLeastValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueInt64() < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("%d is not valid, %s.", req.ConfigValue.ValueInt64(), v.Description(ctx)),
+		)
+	}
+}
+
+func (v int64AtLeastValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for key, elem := range req.ConfigValue.Elements() {
+		intVal, ok := elem.(types.Int64)
+		if !ok || intVal.IsNull() || intVal.IsUnknown() {
+			continue
+		}
+
+		if intVal.ValueInt64() < v.min {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(key),
+				"Invalid Value",
+				fmt.Sprintf("%d is not valid, %s.", intVal.ValueInt64(), v.Description(ctx)),
+			)
+		}
+	}
+}
+
+var _ validator.String = kubeconfigValidator{}
+
+// kubeconfigValidator checks that a string is a parseable kubeconfig with a
+// current context.
+type kubeconfigValidator struct{}
+
+func (v kubeconfigValidator) Description(_ context.Context) string {
+	return "value must be a valid kubeconfig"
+}
+
+func (v kubeconfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v kubeconfigValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := clientcmd.RESTConfigFromKubeConfig([]byte(req.ConfigValue.ValueString())); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Kubeconfig", err.Error())
+	}
+}
+
+var _ validator.String = portRangeValidator{}
+
+// portRangeValidator checks that a string is a low-high port range within
+// 1-65535 with low below high.
+type portRangeValidator struct{}
+
+func (v portRangeValidator) Description(_ context.Context) string {
+	return "value must be a port range in the form low-high within 1-65535"
+}
+
+func (v portRangeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v portRangeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, _, err := parsePortRange(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Port Range", err.Error())
+	}
+}
+
+// parsePortRange parses a low-high port range.
+func parsePortRange(value string) (int64, int64, error) {
+	lowStr, highStr, ok := strings.Cut(value, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("%q must be in the form low-high", value)
+	}
+
+	low, err := strconv.ParseInt(strings.TrimSpace(lowStr), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%q has an invalid low port: %w", value, err)
+	}
+
+	high, err := strconv.ParseInt(strings.TrimSpace(highStr), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%q has an invalid high port: %w", value, err)
+	}
+
+	if low < 1 || high > 65535 || low >= high {
+		return 0, 0, fmt.Errorf("%q must satisfy 1 <= low < high <= 65535", value)
+	}
+
+	return low, high, nil
+}
+
+var (
+	_ validator.String = quantityValidator{}
+	_ validator.Map    = quantityValidator{}
+)
+
+// quantityValidator checks that a string, or every string in a map, is a
+// Kubernetes resource quantity such as 10Mi.
+type quantityValidator struct{}
+
+func (v quantityValidator) Description(_ context.Context) string {
+	return "value must be a Kubernetes quantity such as 10Mi"
+}
+
+func (v quantityValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v quantityValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := resource.ParseQuantity(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Quantity",
+			fmt.Sprintf("%q is not valid, %s: %s", req.ConfigValue.ValueString(), v.Description(ctx), err),
+		)
+	}
+}
+
+func (v quantityValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for key, elem := range req.ConfigValue.Elements() {
+		strVal, ok := elem.(types.String)
+		if !ok || strVal.IsNull() || strVal.IsUnknown() {
+			continue
+		}
+
+		if _, err := resource.ParseQuantity(strVal.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(key),
+				"Invalid Quantity",
+				fmt.Sprintf("%q is not valid, %s: %s", strVal.ValueString(), v.Description(ctx), err),
+			)
+		}
+	}
+}
+
+var _ validator.Int64 = int64BetweenValidator{}
+
+// int64BetweenValidator checks that an integer is within [min, max].
+type int64BetweenValidator struct {
+	min int64
+	max int64
+}
+
+func (v int64BetweenValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be between %d and %d", v.min, v.max)
+}
+
+func (v int64BetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64BetweenValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if value := req.ConfigValue.ValueInt64(); value < v.min || value > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("%d is not valid, %s.", value, v.Description(ctx)),
+		)
+	}
+}
+
+var _ validator.Map = registryCertsValidator{}
+
+// registryCertsValidator checks that a map of registry hosts to CA bundles
+// uses bare host[:port] keys and that every value holds PEM certificates.
+type registryCertsValidator struct{}
+
+func (v registryCertsValidator) Description(_ context.Context) string {
+	return "keys must be registry host[:port] names and values must be PEM encoded CA certificates"
+}
+
+func (v registryCertsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v registryCertsValidator) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for host, elem := range req.ConfigValue.Elements() {
+		if !registryHostPattern.MatchString(host) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(host),
+				"Invalid Registry Host",
+				fmt.Sprintf("%q is not a valid registry host, use host or host:port without a scheme or path.", host),
+			)
+		}
+
+		strVal, ok := elem.(types.String)
+		if !ok || strVal.IsNull() || strVal.IsUnknown() {
+			continue
+		}
+
+		if err := validatePEMCertificates(strVal.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(host),
+				"Invalid CA Certificate",
+				fmt.Sprintf("The CA certificate for %s is not valid: %s", host, err),
+			)
+		}
+	}
+}
+
+// validatePEMCertificates checks that content holds at least one PEM block and
+// that every block is a parseable X.509 certificate.
+func validatePEMCertificates(content string) error {
+	rest := []byte(content)
+	count := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block %q", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return err
+		}
+		count++
+	}
+
+	if count == 0 {
+		return fmt.Errorf("no PEM certificate found")
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return fmt.Errorf("unexpected content after the last PEM certificate")
+	}
+
+	return nil
+}
+
+var _ validator.String = durationValidator{}
+
+// durationValidator checks that a string is a non-negative Go duration such
+// as 30s or 1m30s, the format Kubernetes components take for duration flags.
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a non-negative duration such as 30s or 1m30s"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err == nil && d < 0 {
+		err = fmt.Errorf("duration is negative")
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%q is not valid, %s: %s", req.ConfigValue.ValueString(), v.Description(ctx), err),
+		)
+	}
+}
+
+// dockerHostSchemes are the DOCKER_HOST schemes the Docker CLI accepts.
+var dockerHostSchemes = []string{"unix", "tcp", "ssh", "npipe"}
+
+var _ validator.String = dockerHostValidator{}
+
+// dockerHostValidator checks that a string is a Docker daemon URI with one of
+// the schemes the Docker CLI understands. With lenient set it only warns, for
+// the deprecated host attribute, which accepted any value before.
+type dockerHostValidator struct {
+	lenient bool
+}
+
+func (v dockerHostValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a URI with one of the schemes %s", strings.Join(dockerHostSchemes, ", "))
+}
+
+func (v dockerHostValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dockerHostValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	u, err := url.Parse(value)
+	if err == nil && !slices.Contains(dockerHostSchemes, u.Scheme) {
+		err = fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if err == nil && u.Host == "" && u.Path == "" {
+		err = fmt.Errorf("missing address")
+	}
+
+	if err == nil {
+		return
+	}
+
+	if v.lenient {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Unrecognised Docker Host",
+			fmt.Sprintf("%q may not be understood by the Docker CLI, %s: %s. It is exported as DOCKER_HOST unchanged.", value, v.Description(ctx), err),
+		)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Docker Host",
+		fmt.Sprintf("%q is not valid, %s: %s", value, v.Description(ctx), err),
+	)
+}
+
+var _ validator.String = timezoneValidator{}
+
+// timezoneValidator checks that a string is an IANA timezone name such as
+// Europe/Berlin.
+type timezoneValidator struct{}
+
+func (v timezoneValidator) Description(_ context.Context) string {
+	return "value must be an IANA timezone name such as Europe/Berlin"
+}
+
+func (v timezoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timezoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	name := req.ConfigValue.ValueString()
+
+	// LoadLocation also accepts "" and "Local", which name no zone file.
+	_, err := time.LoadLocation(name)
+	if err == nil && (name == "" || name == "Local") {
+		err = fmt.Errorf("not a zone name")
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timezone",
+			fmt.Sprintf("%q is not valid, %s: %s", name, v.Description(ctx), err),
+		)
+	}
+}
+
+var _ validator.Map = ulimitsValidator{}
+
+// ulimitsValidator checks that a map of ulimits only uses supported names and
+// that every value is a soft:hard pair.
+type ulimitsValidator struct{}
+
+func (v ulimitsValidator) Description(_ context.Context) string {
+	return "keys must be nofile or nproc and values must be soft:hard limits"
+}
+
+func (v ulimitsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ulimitsValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for key, elem := range req.ConfigValue.Elements() {
+		if _, ok := nodeUlimitDirectives[key]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(key),
+				"Unsupported Ulimit",
+				fmt.Sprintf("%q is not supported, %s.", key, v.Description(ctx)),
+			)
+			continue
+		}
+
+		strVal, ok := elem.(types.String)
+		if !ok || strVal.IsNull() || strVal.IsUnknown() {
+			continue
+		}
+
+		if _, _, err := parseUlimit(strVal.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(key),
+				"Invalid Ulimit",
+				fmt.Sprintf("%q is not valid: %s.", strVal.ValueString(), err),
+			)
+		}
+	}
+}
+
+// releaseVersionRegexp matches release tags such as v1.19.1 or v1.20.0-beta.0.
+var releaseVersionRegexp = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.]+)?$`)
+
+var _ validator.String = releaseVersionValidator{}
+
+// releaseVersionValidator checks that a string is a release tag such as
+// v1.19.1.
+type releaseVersionValidator struct{}
+
+func (v releaseVersionValidator) Description(_ context.Context) string {
+	return "value must be a release version such as v1.19.1"
+}
+
+func (v releaseVersionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v releaseVersionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !releaseVersionRegexp.MatchString(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Version",
+			fmt.Sprintf("%q is not valid, %s.", req.ConfigValue.ValueString(), v.Description(ctx)),
+		)
+	}
+}
+
+var _ validator.List = dnsUpstreamValidator{}
+
+// dnsUpstreamValidator checks that every element of a list is an upstream
+// the CoreDNS forward plugin accepts.
+type dnsUpstreamValidator struct{}
+
+func (v dnsUpstreamValidator) Description(_ context.Context) string {
+	return "values must be IP addresses with an optional port, optionally prefixed with dns:// or tls://"
+}
+
+func (v dnsUpstreamValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsUpstreamValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, elem := range req.ConfigValue.Elements() {
+		strVal, ok := elem.(types.String)
+		if !ok || strVal.IsNull() || strVal.IsUnknown() {
+			continue
+		}
+
+		if err := validateDNSUpstream(strVal.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i),
+				"Invalid DNS Upstream",
+				fmt.Sprintf("%q is not valid, %s: %s", strVal.ValueString(), v.Description(ctx), err),
+			)
+		}
+	}
+}
+
+var _ validator.List = namespacedNameValidator{}
+
+// namespacedNameValidator checks that every element of a list is a
+// namespace/name reference to a Kubernetes object.
+type namespacedNameValidator struct{}
+
+func (v namespacedNameValidator) Description(_ context.Context) string {
+	return "values must be namespace/name references such as kube-system/coredns"
+}
+
+func (v namespacedNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v namespacedNameValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, elem := range req.ConfigValue.Elements() {
+		strVal, ok := elem.(types.String)
+		if !ok || strVal.IsNull() || strVal.IsUnknown() {
+			continue
+		}
+
+		var errs []string
+		namespace, name, found := strings.Cut(strVal.ValueString(), "/")
+		if !found {
+			errs = append(errs, "missing the namespace/ prefix")
+		} else {
+			errs = append(errs, validation.IsDNS1123Label(namespace)...)
+			errs = append(errs, validation.IsDNS1123Subdomain(name)...)
+		}
+
+		if len(errs) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i),
+				"Invalid Object Reference",
+				fmt.Sprintf("%q is not valid, %s: %s", strVal.ValueString(), v.Description(ctx), strings.Join(errs, "; ")),
+			)
+		}
+	}
+}
+
+var _ validator.String = pemCertificatesValidator{}
+
+// pemCertificatesValidator checks that a string holds one or more PEM encoded
+// X.509 certificates.
+type pemCertificatesValidator struct{}
+
+func (v pemCertificatesValidator) Description(_ context.Context) string {
+	return "value must be one or more PEM encoded certificates"
+}
+
+func (v pemCertificatesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v pemCertificatesValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validatePEMCertificates(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CA Certificate",
+			fmt.Sprintf("The CA bundle is not valid: %s", err),
+		)
+	}
+}
+
+var _ validator.String = regexValidator{}
+
+// regexValidator checks that a string is a valid Go regular expression.
+type regexValidator struct{}
+
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("%q is not valid, %s: %s", req.ConfigValue.ValueString(), v.Description(ctx), err),
+		)
+	}
+}
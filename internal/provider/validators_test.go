@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestKubeProxyModeValidator(t *testing.T) {
+	v := stringOneOfValidator{values: kubeProxyModes, allowEmpty: true}
+
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{"null", types.StringNull(), false},
+		{"unknown", types.StringUnknown(), false},
+		{"empty", types.StringValue(""), false},
+		{"iptables", types.StringValue("iptables"), false},
+		{"ipvs", types.StringValue("ipvs"), false},
+		{"nftables", types.StringValue("nftables"), false},
+		{"none", types.StringValue("none"), false},
+		{"invalid", types.StringValue("userspace"), true},
+		{"wrong case", types.StringValue("IPVS"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("networking").AtName("kube_proxy_mode"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+			v.ValidateString(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+				t.Errorf("ValidateString(%s) error = %v, want %v: %v", tt.value, got, tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestStringOneOfValidatorRejectsEmpty(t *testing.T) {
+	v := stringOneOfValidator{values: []string{"a", "b"}}
+
+	resp := &validator.StringResponse{}
+	v.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("x"), ConfigValue: types.StringValue("")}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("empty value accepted without allowEmpty")
+	}
+}
+
+func TestDockerHostValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{"null", types.StringNull(), false},
+		{"unix", types.StringValue("unix:///var/run/docker.sock"), false},
+		{"tcp", types.StringValue("tcp://localhost:2375"), false},
+		{"ssh", types.StringValue("ssh://user@host"), false},
+		{"npipe", types.StringValue("npipe:////./pipe/docker_engine"), false},
+		{"empty", types.StringValue(""), true},
+		{"bare address", types.StringValue("localhost:2375"), true},
+		{"other scheme", types.StringValue("http://localhost:2375"), true},
+		{"missing address", types.StringValue("tcp://"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			dockerHostValidator{}.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("docker_host"), ConfigValue: tt.value}, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+				t.Errorf("ValidateString(%s) error = %v, want %v: %v", tt.value, got, tt.wantErr, resp.Diagnostics)
+			}
+
+			// The deprecated host attribute accepted any value before
+			// docker_host existed, so it only warns.
+			lenient := &validator.StringResponse{}
+			dockerHostValidator{lenient: true}.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("host"), ConfigValue: tt.value}, lenient)
+
+			if lenient.Diagnostics.HasError() {
+				t.Errorf("lenient ValidateString(%s) returned an error: %v", tt.value, lenient.Diagnostics)
+			}
+			if got := lenient.Diagnostics.WarningsCount() > 0; got != tt.wantErr {
+				t.Errorf("lenient ValidateString(%s) warning = %v, want %v", tt.value, got, tt.wantErr)
+			}
+		})
+	}
+}
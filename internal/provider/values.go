@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listStringValues converts a Terraform list of strings into a Go slice,
+// skipping null and unknown elements.
+func listStringValues(l types.List) []string {
+	if l.IsNull() || l.IsUnknown() {
+		return nil
+	}
+
+	values := make([]string, 0, len(l.Elements()))
+	for _, elem := range l.Elements() {
+		if strVal, ok := elem.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
+			values = append(values, strVal.ValueString())
+		}
+	}
+
+	return values
+}
+
+// stringMapValue converts a Terraform map of strings into a Go map, skipping
+// null and unknown elements.
+func stringMapValue(m types.Map) map[string]string {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+
+	result := make(map[string]string, len(m.Elements()))
+	for k, v := range m.Elements() {
+		if strVal, ok := v.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
+			result[k] = strVal.ValueString()
+		}
+	}
+
+	return result
+}
+
+// int64MapValue converts a Terraform map of integers into a Go map, skipping
+// null and unknown elements.
+func int64MapValue(m types.Map) map[string]int64 {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+
+	result := make(map[string]int64, len(m.Elements()))
+	for k, v := range m.Elements() {
+		if intVal, ok := v.(types.Int64); ok && !intVal.IsNull() && !intVal.IsUnknown() {
+			result[k] = intVal.ValueInt64()
+		}
+	}
+
+	return result
+}
+
+// boolMapValue converts a Terraform map of booleans into a Go map, skipping
+// null and unknown elements.
+func boolMapValue(m types.Map) map[string]bool {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+
+	result := make(map[string]bool, len(m.Elements()))
+	for k, v := range m.Elements() {
+		if boolVal, ok := v.(types.Bool); ok && !boolVal.IsNull() && !boolVal.IsUnknown() {
+			result[k] = boolVal.ValueBool()
+		}
+	}
+
+	return result
+}